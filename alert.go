@@ -3,6 +3,8 @@ package oncall
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"time"
 )
 
 type AlertResource struct {
@@ -54,6 +56,34 @@ func (a *AlertResource) Get(ctx context.Context, alertID string) (*Alert, error)
 	return &result.Alert, nil
 }
 
+// Trigger opens a new alert. When ExternalID matches an alert that is still
+// open, the server updates that alert instead and reports Deduplicated.
+func (a *AlertResource) Trigger(ctx context.Context, input TriggerAlertInput) (*TriggerAlertResult, error) {
+	if input.Title == "" {
+		return nil, &ValidationError{OnCallError: OnCallError{Message: "alert title is required"}}
+	}
+	if !input.Severity.valid() {
+		return nil, &ValidationError{OnCallError: OnCallError{Message: fmt.Sprintf("invalid alert severity %q", input.Severity)}}
+	}
+
+	var result TriggerAlertResult
+	if err := a.http.post(ctx, "/alerts", input, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (a *AlertResource) ResolveByExternalID(ctx context.Context, externalID string) (*Alert, error) {
+	var result struct {
+		Alert Alert `json:"alert"`
+	}
+	path := fmt.Sprintf("/alerts/external/%s/resolve", url.PathEscape(externalID))
+	if err := a.http.post(ctx, path, struct{}{}, &result); err != nil {
+		return nil, err
+	}
+	return &result.Alert, nil
+}
+
 func (a *AlertResource) Acknowledge(ctx context.Context, alertID string, input *AcknowledgeAlertInput) (*Alert, error) {
 	var result struct {
 		Alert Alert `json:"alert"`
@@ -69,6 +99,17 @@ func (a *AlertResource) Acknowledge(ctx context.Context, alertID string, input *
 	return &result.Alert, nil
 }
 
+func (a *AlertResource) Unacknowledge(ctx context.Context, alertID string) (*Alert, error) {
+	var result struct {
+		Alert Alert `json:"alert"`
+	}
+	path := fmt.Sprintf("/alerts/%s/unacknowledge", alertID)
+	if err := a.http.post(ctx, path, struct{}{}, &result); err != nil {
+		return nil, err
+	}
+	return &result.Alert, nil
+}
+
 func (a *AlertResource) Resolve(ctx context.Context, alertID string) (*Alert, error) {
 	var result struct {
 		Alert Alert `json:"alert"`
@@ -80,6 +121,38 @@ func (a *AlertResource) Resolve(ctx context.Context, alertID string) (*Alert, er
 	return &result.Alert, nil
 }
 
+func (a *AlertResource) Reopen(ctx context.Context, alertID string) (*Alert, error) {
+	var result struct {
+		Alert Alert `json:"alert"`
+	}
+	path := fmt.Sprintf("/alerts/%s/reopen", alertID)
+	if err := a.http.post(ctx, path, struct{}{}, &result); err != nil {
+		return nil, err
+	}
+	return &result.Alert, nil
+}
+
+// Snooze silences an alert for the given duration, which the API takes in
+// whole seconds. When it elapses the server re-triggers the alert's relay from
+// the first rule.
+func (a *AlertResource) Snooze(ctx context.Context, alertID string, duration time.Duration) (*Alert, error) {
+	if duration <= 0 || duration%time.Second != 0 {
+		return nil, &ValidationError{OnCallError: OnCallError{
+			Message: fmt.Sprintf("snooze duration must be a positive number of whole seconds, got %s", duration),
+		}}
+	}
+
+	var result struct {
+		Alert Alert `json:"alert"`
+	}
+	path := fmt.Sprintf("/alerts/%s/snooze", alertID)
+	body := map[string]int64{"duration": int64(duration / time.Second)}
+	if err := a.http.post(ctx, path, body, &result); err != nil {
+		return nil, err
+	}
+	return &result.Alert, nil
+}
+
 func (a *AlertResource) Assign(ctx context.Context, alertID string, userID string) (*Alert, error) {
 	var result struct {
 		Alert Alert `json:"alert"`
@@ -92,6 +165,45 @@ func (a *AlertResource) Assign(ctx context.Context, alertID string, userID strin
 	return &result.Alert, nil
 }
 
+func (a *AlertResource) AddNote(ctx context.Context, alertID string, input AddAlertNoteInput) (*AlertNote, error) {
+	if input.Body == "" {
+		return nil, &ValidationError{OnCallError: OnCallError{Message: "note body is required"}}
+	}
+
+	var result struct {
+		Note AlertNote `json:"note"`
+	}
+	path := fmt.Sprintf("/alerts/%s/notes", alertID)
+	if err := a.http.post(ctx, path, input, &result); err != nil {
+		return nil, err
+	}
+	return &result.Note, nil
+}
+
+func (a *AlertResource) ListNotes(ctx context.Context, alertID string) ([]AlertNote, error) {
+	var result struct {
+		Notes []AlertNote `json:"notes"`
+	}
+	path := fmt.Sprintf("/alerts/%s/notes", alertID)
+	if err := a.http.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+	return result.Notes, nil
+}
+
+// Timeline returns the alert's history in chronological order. Notification
+// events carry the RelayRuleID of the rule that sent them.
+func (a *AlertResource) Timeline(ctx context.Context, alertID string) ([]AlertEvent, error) {
+	var result struct {
+		Events []AlertEvent `json:"events"`
+	}
+	path := fmt.Sprintf("/alerts/%s/timeline", alertID)
+	if err := a.http.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+	return result.Events, nil
+}
+
 func (a *AlertResource) ListSafe(ctx context.Context) Result[[]Alert] {
 	alerts, err := a.List(ctx)
 	if err != nil {
@@ -124,6 +236,22 @@ func (a *AlertResource) GetSafe(ctx context.Context, alertID string) Result[Aler
 	return Result[Alert]{Data: alert}
 }
 
+func (a *AlertResource) TriggerSafe(ctx context.Context, input TriggerAlertInput) Result[TriggerAlertResult] {
+	result, err := a.Trigger(ctx, input)
+	if err != nil {
+		return Result[TriggerAlertResult]{Error: err}
+	}
+	return Result[TriggerAlertResult]{Data: result}
+}
+
+func (a *AlertResource) ResolveByExternalIDSafe(ctx context.Context, externalID string) Result[Alert] {
+	alert, err := a.ResolveByExternalID(ctx, externalID)
+	if err != nil {
+		return Result[Alert]{Error: err}
+	}
+	return Result[Alert]{Data: alert}
+}
+
 func (a *AlertResource) AcknowledgeSafe(ctx context.Context, alertID string, input *AcknowledgeAlertInput) Result[Alert] {
 	alert, err := a.Acknowledge(ctx, alertID, input)
 	if err != nil {
@@ -140,6 +268,30 @@ func (a *AlertResource) ResolveSafe(ctx context.Context, alertID string) Result[
 	return Result[Alert]{Data: alert}
 }
 
+func (a *AlertResource) UnacknowledgeSafe(ctx context.Context, alertID string) Result[Alert] {
+	alert, err := a.Unacknowledge(ctx, alertID)
+	if err != nil {
+		return Result[Alert]{Error: err}
+	}
+	return Result[Alert]{Data: alert}
+}
+
+func (a *AlertResource) ReopenSafe(ctx context.Context, alertID string) Result[Alert] {
+	alert, err := a.Reopen(ctx, alertID)
+	if err != nil {
+		return Result[Alert]{Error: err}
+	}
+	return Result[Alert]{Data: alert}
+}
+
+func (a *AlertResource) SnoozeSafe(ctx context.Context, alertID string, duration time.Duration) Result[Alert] {
+	alert, err := a.Snooze(ctx, alertID, duration)
+	if err != nil {
+		return Result[Alert]{Error: err}
+	}
+	return Result[Alert]{Data: alert}
+}
+
 func (a *AlertResource) AssignSafe(ctx context.Context, alertID string, userID string) Result[Alert] {
 	alert, err := a.Assign(ctx, alertID, userID)
 	if err != nil {
@@ -147,3 +299,35 @@ func (a *AlertResource) AssignSafe(ctx context.Context, alertID string, userID s
 	}
 	return Result[Alert]{Data: alert}
 }
+
+func (a *AlertResource) AddNoteSafe(ctx context.Context, alertID string, input AddAlertNoteInput) Result[AlertNote] {
+	note, err := a.AddNote(ctx, alertID, input)
+	if err != nil {
+		return Result[AlertNote]{Error: err}
+	}
+	return Result[AlertNote]{Data: note}
+}
+
+func (a *AlertResource) ListNotesSafe(ctx context.Context, alertID string) Result[[]AlertNote] {
+	notes, err := a.ListNotes(ctx, alertID)
+	if err != nil {
+		return Result[[]AlertNote]{Error: err}
+	}
+	return Result[[]AlertNote]{Data: &notes}
+}
+
+func (a *AlertResource) TimelineSafe(ctx context.Context, alertID string) Result[[]AlertEvent] {
+	events, err := a.Timeline(ctx, alertID)
+	if err != nil {
+		return Result[[]AlertEvent]{Error: err}
+	}
+	return Result[[]AlertEvent]{Data: &events}
+}
+
+func (s AlertSeverity) valid() bool {
+	switch s {
+	case SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow, SeverityInfo:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package oncall
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+const defaultBulkConcurrency = 5
+
+// BulkAcknowledge acknowledges every selected alert and reports the outcome per
+// alert ID. A failure for one alert does not stop the others; the returned
+// error is only set when the selection itself could not be resolved.
+func (a *AlertResource) BulkAcknowledge(ctx context.Context, input BulkAlertInput, ack *AcknowledgeAlertInput) (map[string]Result[Alert], error) {
+	return a.bulk(ctx, input, func(ctx context.Context, alertID string) (*Alert, error) {
+		return a.Acknowledge(ctx, alertID, ack)
+	})
+}
+
+func (a *AlertResource) BulkResolve(ctx context.Context, input BulkAlertInput) (map[string]Result[Alert], error) {
+	return a.bulk(ctx, input, a.Resolve)
+}
+
+func (a *AlertResource) BulkAssign(ctx context.Context, input BulkAlertInput, userID string) (map[string]Result[Alert], error) {
+	return a.bulk(ctx, input, func(ctx context.Context, alertID string) (*Alert, error) {
+		return a.Assign(ctx, alertID, userID)
+	})
+}
+
+func (a *AlertResource) bulk(ctx context.Context, input BulkAlertInput, op func(context.Context, string) (*Alert, error)) (map[string]Result[Alert], error) {
+	ids, err := a.selectAlertIDs(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	concurrency := input.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultBulkConcurrency
+	}
+
+	results := make(map[string]Result[Alert], len(ids))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	jobs := make(chan string)
+
+	for range min(concurrency, len(ids)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range jobs {
+				var result Result[Alert]
+				if err := ctx.Err(); err != nil {
+					result.Error = err
+				} else if alert, err := op(ctx, id); err != nil {
+					result.Error = err
+				} else {
+					result.Data = alert
+				}
+				mu.Lock()
+				results[id] = result
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, id := range ids {
+		jobs <- id
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results, nil
+}
+
+func (a *AlertResource) selectAlertIDs(ctx context.Context, input BulkAlertInput) ([]string, error) {
+	if len(input.AlertIDs) > 0 && input.Filter != nil {
+		return nil, &ValidationError{OnCallError: OnCallError{Message: "specify either alert IDs or a filter, not both"}}
+	}
+	if input.Filter == nil {
+		ids := slices.Clone(input.AlertIDs)
+		slices.Sort(ids)
+		return slices.Compact(ids), nil
+	}
+
+	alerts, err := a.ListActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, alert := range alerts {
+		if input.Filter.matches(alert) {
+			ids = append(ids, alert.ID)
+		}
+	}
+	return ids, nil
+}
+
+func (f *AlertFilter) matches(alert Alert) bool {
+	if len(f.Severities) > 0 && !slices.Contains(f.Severities, alert.Severity) {
+		return false
+	}
+	if f.Source != nil && alert.Source != *f.Source {
+		return false
+	}
+	if f.Match != nil && !f.Match(alert) {
+		return false
+	}
+	return true
+}
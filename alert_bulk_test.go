@@ -0,0 +1,67 @@
+package oncall
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBulkResolve(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		id := strings.Split(strings.TrimPrefix(r.URL.Path, "/alerts/"), "/")[0]
+		if id == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"alert not found"}`))
+			return
+		}
+		w.Write([]byte(`{"alert":{"id":"` + id + `"}}`))
+	})
+
+	ids := []string{"a1", "a2", "missing", "a3", "a4", "a5"}
+	results, err := client.Alert.BulkResolve(context.Background(), BulkAlertInput{AlertIDs: ids, Concurrency: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != len(ids) {
+		t.Fatalf("expected %d results, got %d", len(ids), len(results))
+	}
+	if _, ok := results["missing"].Error.(*NotFoundError); !ok {
+		t.Fatalf("expected NotFoundError for missing alert, got %v", results["missing"].Error)
+	}
+	for _, id := range []string{"a1", "a2", "a3", "a4", "a5"} {
+		if results[id].Error != nil || results[id].Data.ID != id {
+			t.Fatalf("unexpected result for %s: %+v", id, results[id])
+		}
+	}
+	if peak.Load() > 2 {
+		t.Fatalf("expected at most 2 concurrent requests, saw %d", peak.Load())
+	}
+}
+
+func TestBulkRejectsIDsAndFilter(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("unexpected request %s", r.URL.Path)
+	})
+
+	_, err := client.Alert.BulkResolve(context.Background(), BulkAlertInput{
+		AlertIDs: []string{"a1"},
+		Filter:   &AlertFilter{},
+	})
+	if _, ok := err.(*ValidationError); !ok {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+}
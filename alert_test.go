@@ -0,0 +1,206 @@
+package oncall
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTriggerAlert(t *testing.T) {
+	t.Run("sends external ID and decodes deduplication", func(t *testing.T) {
+		var body map[string]any
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost || r.URL.Path != "/alerts" {
+				t.Fatalf("unexpected %s %s", r.Method, r.URL.Path)
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			w.Write([]byte(`{"alert":{"id":"a1","title":"Disk full","externalId":"disk-1"},"deduplicated":true}`))
+		})
+
+		externalID := "disk-1"
+		result, err := client.Alert.Trigger(context.Background(), TriggerAlertInput{
+			Title:      "Disk full",
+			Severity:   SeverityHigh,
+			Source:     "monitor",
+			ExternalID: &externalID,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if body["externalId"] != "disk-1" || body["title"] != "Disk full" || body["severity"] != "high" {
+			t.Fatalf("unexpected request body %v", body)
+		}
+		if !result.Deduplicated {
+			t.Fatal("expected Deduplicated to be true")
+		}
+		if result.Alert.ID != "a1" || result.Alert.ExternalID == nil || *result.Alert.ExternalID != "disk-1" {
+			t.Fatalf("unexpected alert %+v", result.Alert)
+		}
+	})
+
+	t.Run("validates locally", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			t.Fatalf("unexpected %s %s", r.Method, r.URL.Path)
+		})
+
+		inputs := map[string]TriggerAlertInput{
+			"empty title":  {Severity: SeverityHigh},
+			"bad severity": {Title: "Disk full", Severity: "urgent"},
+		}
+		for name, input := range inputs {
+			_, err := client.Alert.Trigger(context.Background(), input)
+			if _, ok := err.(*ValidationError); !ok {
+				t.Errorf("%s: expected *ValidationError, got %v", name, err)
+			}
+		}
+	})
+}
+
+func TestResolveByExternalID(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("unexpected method %s", r.Method)
+		}
+		if got, want := r.URL.EscapedPath(), "/alerts/external/deploy%2F42%20%231/resolve"; got != want {
+			t.Fatalf("path = %s, want %s", got, want)
+		}
+		w.Write([]byte(`{"alert":{"id":"a1","externalId":"deploy/42 #1"}}`))
+	})
+
+	alert, err := client.Alert.ResolveByExternalID(context.Background(), "deploy/42 #1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if alert.ID != "a1" {
+		t.Fatalf("unexpected alert %+v", alert)
+	}
+}
+
+func TestAlertNotes(t *testing.T) {
+	var body map[string]any
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/alerts/a1/notes":
+			json.NewDecoder(r.Body).Decode(&body)
+			w.Write([]byte(`{"note":{"id":"n1","alertId":"a1","userId":"u1","body":"Restarted the worker","createdAt":"2026-01-05T09:00:00Z"}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/alerts/a1/notes":
+			w.Write([]byte(`{"notes":[{"id":"n1","alertId":"a1","body":"Restarted the worker"},{"id":"n2","alertId":"a1","body":"Still failing"}]}`))
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	ctx := context.Background()
+
+	userID := "u1"
+	note, err := client.Alert.AddNote(ctx, "a1", AddAlertNoteInput{Body: "Restarted the worker", UserID: &userID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body["body"] != "Restarted the worker" || body["userId"] != "u1" {
+		t.Fatalf("unexpected request body %v", body)
+	}
+	if note.ID != "n1" || note.UserID == nil || *note.UserID != "u1" || note.CreatedAt.IsZero() {
+		t.Fatalf("unexpected note %+v", note)
+	}
+
+	notes, err := client.Alert.ListNotes(ctx, "a1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(notes) != 2 || notes[1].Body != "Still failing" {
+		t.Fatalf("unexpected notes %+v", notes)
+	}
+
+	_, err = client.Alert.AddNote(ctx, "a1", AddAlertNoteInput{})
+	if _, ok := err.(*ValidationError); !ok {
+		t.Fatalf("expected *ValidationError for an empty note, got %v", err)
+	}
+}
+
+func TestAlertTimeline(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/alerts/a1/timeline" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"events":[
+			{"id":"e1","alertId":"a1","type":"triggered","createdAt":"2026-01-05T09:00:00Z"},
+			{"id":"e2","alertId":"a1","type":"notified","relayRuleId":"rule1","targetUserId":"u1","notificationMethod":"sms","createdAt":"2026-01-05T09:00:05Z"}
+		]}`))
+	})
+
+	events, err := client.Alert.Timeline(context.Background(), "a1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 2 || events[0].Type != AlertEventTriggered {
+		t.Fatalf("unexpected events %+v", events)
+	}
+	notified := events[1]
+	if notified.Type != AlertEventNotified || notified.RelayRuleID == nil || *notified.RelayRuleID != "rule1" ||
+		notified.NotificationMethod == nil || *notified.NotificationMethod != NotificationSMS {
+		t.Fatalf("unexpected notification event %+v", notified)
+	}
+}
+
+func TestAlertStateChanges(t *testing.T) {
+	var requests []string
+	var snooze map[string]any
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		requests = append(requests, r.URL.Path)
+		switch r.URL.Path {
+		case "/alerts/a1/unacknowledge":
+			w.Write([]byte(`{"alert":{"id":"a1"}}`))
+		case "/alerts/a1/reopen":
+			w.Write([]byte(`{"alert":{"id":"a1"}}`))
+		case "/alerts/a1/snooze":
+			json.NewDecoder(r.Body).Decode(&snooze)
+			w.Write([]byte(`{"alert":{"id":"a1","snoozedUntil":"2026-01-05T09:30:00Z"}}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	ctx := context.Background()
+
+	if alert, err := client.Alert.Unacknowledge(ctx, "a1"); err != nil || alert.ID != "a1" {
+		t.Fatalf("Unacknowledge: %+v, %v", alert, err)
+	}
+	if alert, err := client.Alert.Reopen(ctx, "a1"); err != nil || alert.ID != "a1" {
+		t.Fatalf("Reopen: %+v, %v", alert, err)
+	}
+	alert, err := client.Alert.Snooze(ctx, "a1", 30*time.Minute)
+	if err != nil {
+		t.Fatalf("Snooze: %v", err)
+	}
+	if snooze["duration"] != float64(1800) {
+		t.Fatalf("expected a duration of 1800 seconds, got %v", snooze)
+	}
+	if alert.SnoozedUntil == nil || !alert.SnoozedUntil.Equal(time.Date(2026, 1, 5, 9, 30, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected alert %+v", alert)
+	}
+
+	for _, d := range []time.Duration{0, -time.Minute, 500 * time.Millisecond, 1500 * time.Millisecond} {
+		_, err := client.Alert.Snooze(ctx, "a1", d)
+		if _, ok := err.(*ValidationError); !ok {
+			t.Errorf("expected *ValidationError for %s, got %v", d, err)
+		}
+	}
+
+	want := []string{"/alerts/a1/unacknowledge", "/alerts/a1/reopen", "/alerts/a1/snooze"}
+	if !slices.Equal(requests, want) {
+		t.Fatalf("requests = %v, want %v", requests, want)
+	}
+}
@@ -11,6 +11,15 @@ type Config struct {
 	Timeout    time.Duration
 	MaxRetries int
 	BackoffMs  int
+
+	// SkipRuleValidation disables the client-side checks run before relay
+	// rules are created or updated, leaving validation to the server.
+	SkipRuleValidation bool
+
+	// SkipContactValueValidation disables the format checks run on contact
+	// method values before they are created or changed (see
+	// ValidateContactValue), leaving validation to the server.
+	SkipContactValueValidation bool
 }
 
 type Client struct {
@@ -19,6 +28,8 @@ type Client struct {
 	ContactMethod *ContactMethodResource
 	Alert         *AlertResource
 	Integration   *IntegrationResource
+	User          *UserResource
+	Webhook       *WebhookResource
 }
 
 func NewClient(cfg Config) (*Client, error) {
@@ -29,10 +40,12 @@ func NewClient(cfg Config) (*Client, error) {
 	http := newHTTPClient(&cfg)
 
 	return &Client{
-		Relay:         newRelayResource(http),
+		Relay:         newRelayResource(http, !cfg.SkipRuleValidation),
 		Schedule:      newScheduleResource(http),
-		ContactMethod: newContactMethodResource(http),
+		ContactMethod: newContactMethodResource(http, !cfg.SkipContactValueValidation),
 		Alert:         newAlertResource(http),
 		Integration:   newIntegrationResource(http),
+		User:          newUserResource(http),
+		Webhook:       newWebhookResource(http),
 	}, nil
 }
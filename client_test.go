@@ -2,9 +2,32 @@ package oncall
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
 	"testing"
 )
 
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	return newTestClientWithConfig(t, Config{}, handler)
+}
+
+func newTestClientWithConfig(t *testing.T, cfg Config, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	cfg.APIKey = "test-key"
+	cfg.BaseURL = server.URL
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return client
+}
+
 func TestNewClient(t *testing.T) {
 	t.Run("requires API key", func(t *testing.T) {
 		_, err := NewClient(Config{})
@@ -30,6 +53,9 @@ func TestNewClient(t *testing.T) {
 		if client.Schedule == nil {
 			t.Fatal("expected Schedule resource to be initialized")
 		}
+		if client.Schedule.Overrides == nil {
+			t.Fatal("expected Schedule.Overrides resource to be initialized")
+		}
 		if client.ContactMethod == nil {
 			t.Fatal("expected ContactMethod resource to be initialized")
 		}
@@ -39,6 +65,12 @@ func TestNewClient(t *testing.T) {
 		if client.Integration == nil {
 			t.Fatal("expected Integration resource to be initialized")
 		}
+		if client.User == nil {
+			t.Fatal("expected User resource to be initialized")
+		}
+		if client.Webhook == nil {
+			t.Fatal("expected Webhook resource to be initialized")
+		}
 	})
 }
 
@@ -136,6 +168,113 @@ func TestResultType(t *testing.T) {
 	})
 }
 
+func TestContactMethodVerification(t *testing.T) {
+	t.Run("rejects already verified method", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				t.Fatalf("unexpected %s %s", r.Method, r.URL.Path)
+			}
+			w.Write([]byte(`{"contactMethods":[{"id":"cm1","userId":"u1","verified":true}]}`))
+		})
+
+		err := client.ContactMethod.SendVerification(context.Background(), "cm1", SendContactMethodVerificationInput{UserID: "u1"})
+		if !errors.Is(err, ErrContactMethodAlreadyVerified) {
+			t.Fatalf("expected ErrContactMethodAlreadyVerified, got %v", err)
+		}
+		if _, ok := err.(*ValidationError); !ok {
+			t.Fatalf("expected *ValidationError, got %T", err)
+		}
+	})
+
+	t.Run("sends verification for unverified method", func(t *testing.T) {
+		sent := false
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/contact-methods":
+				w.Write([]byte(`{"contactMethods":[{"id":"cm1","userId":"u1","verified":false}]}`))
+			case "/contact-methods/cm1/verification":
+				sent = true
+				w.Write([]byte(`{"success":true}`))
+			default:
+				t.Fatalf("unexpected path %s", r.URL.Path)
+			}
+		})
+
+		err := client.ContactMethod.SendVerification(context.Background(), "cm1", SendContactMethodVerificationInput{UserID: "u1"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !sent {
+			t.Fatal("expected verification request to be sent")
+		}
+	})
+}
+
+func TestContactMethodValueValidation(t *testing.T) {
+	t.Run("update checks value against the method's transport", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write([]byte(`{"contactMethods":[{"id":"cm1","userId":"u1","transport":"sms","value":"+14155550123"}]}`))
+		})
+
+		value := "not-a-number"
+		_, err := client.ContactMethod.Update(context.Background(), "cm1", UpdateContactMethodInput{UserID: "u1", Value: &value})
+		if _, ok := err.(*ValidationError); !ok {
+			t.Fatalf("expected *ValidationError, got %v", err)
+		}
+	})
+
+	t.Run("update with a known transport skips the lookup", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		})
+
+		value := "not-a-number"
+		_, err := client.ContactMethod.Update(context.Background(), "cm1", UpdateContactMethodInput{UserID: "u1", Value: &value, Transport: TransportSMS})
+		if _, ok := err.(*ValidationError); !ok {
+			t.Fatalf("expected *ValidationError, got %v", err)
+		}
+	})
+
+	t.Run("rule validation flag does not apply", func(t *testing.T) {
+		client := newTestClientWithConfig(t, Config{SkipRuleValidation: true}, func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		})
+
+		_, err := client.ContactMethod.Create(context.Background(), CreateContactMethodInput{UserID: "u1", Transport: TransportSMS, Value: "555"})
+		if _, ok := err.(*ValidationError); !ok {
+			t.Fatalf("expected *ValidationError, got %v", err)
+		}
+	})
+
+	t.Run("skipped with SkipContactValueValidation", func(t *testing.T) {
+		var paths []string
+		client := newTestClientWithConfig(t, Config{SkipContactValueValidation: true}, func(w http.ResponseWriter, r *http.Request) {
+			paths = append(paths, r.Method+" "+r.URL.Path)
+			w.Write([]byte(`{"contactMethod":{"id":"cm1"}}`))
+		})
+
+		ctx := context.Background()
+		if _, err := client.ContactMethod.Create(ctx, CreateContactMethodInput{UserID: "u1", Transport: TransportSMS, Value: "555"}); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		value := "555"
+		if _, err := client.ContactMethod.Update(ctx, "cm1", UpdateContactMethodInput{UserID: "u1", Value: &value}); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		want := []string{"POST /contact-methods", "PUT /contact-methods/cm1"}
+		if !slices.Equal(paths, want) {
+			t.Fatalf("requests = %v, want %v", paths, want)
+		}
+	})
+}
+
 func ExampleClient() {
 	client, err := NewClient(Config{
 		APIKey: "your-api-key",
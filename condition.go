@@ -0,0 +1,166 @@
+package oncall
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Conditions understood by ParseCondition and ConditionalConfig.Evaluate.
+// Their values are:
+//
+//	acknowledged      no value
+//	severity          an AlertSeverity, e.g. "critical"
+//	metadata_matches  "key=pattern", pattern being a regular expression
+//	time_of_day       "HH:MM-HH:MM" optionally followed by an IANA time zone,
+//	                  e.g. "09:00-17:00 Europe/Berlin"; UTC by default
+//
+// This grammar is the client's own convention, shared by the constructors
+// below, ParseCondition and the simulator. It is not taken from the server,
+// which may name or encode conditions differently, so ConditionalConfig.Validate
+// does not check condition values and leaves them to the server.
+const (
+	ConditionAcknowledged    = "acknowledged"
+	ConditionSeverity        = "severity"
+	ConditionMetadataMatches = "metadata_matches"
+	ConditionTimeOfDay       = "time_of_day"
+)
+
+func IfAcknowledged() ConditionalConfig {
+	return ConditionalConfig{Condition: ConditionAcknowledged}
+}
+
+func IfSeverity(severity AlertSeverity) ConditionalConfig {
+	value := string(severity)
+	return ConditionalConfig{Condition: ConditionSeverity, ConditionValue: &value}
+}
+
+// IfMetadataMatches is true when the alert's metadata value for key, as
+// text, matches the regular expression pattern.
+func IfMetadataMatches(key, pattern string) ConditionalConfig {
+	value := key + "=" + pattern
+	return ConditionalConfig{Condition: ConditionMetadataMatches, ConditionValue: &value}
+}
+
+// IfTimeOfDay is true from start (inclusive) to end (exclusive) in loc, or
+// UTC if loc is nil. Windows with end before start span midnight.
+func IfTimeOfDay(start, end TimeOfDay, loc *time.Location) ConditionalConfig {
+	value := start.String() + "-" + end.String()
+	if loc != nil && loc != time.UTC && loc.String() != "" {
+		value += " " + loc.String()
+	}
+	return ConditionalConfig{Condition: ConditionTimeOfDay, ConditionValue: &value}
+}
+
+// Condition is a parsed conditional rule condition. Only the fields of its
+// Name are set.
+type Condition struct {
+	Name string
+
+	// Severity is set for severity conditions.
+	Severity AlertSeverity
+
+	// MetadataKey and MetadataPattern are set for metadata_matches conditions.
+	MetadataKey     string
+	MetadataPattern *regexp.Regexp
+
+	// Start, End and Location are set for time_of_day conditions.
+	Start, End TimeOfDay
+	Location   *time.Location
+}
+
+// ParseCondition parses a condition and its value in the grammar above.
+// Conditions it does not know are an error.
+func ParseCondition(condition string, value *string) (*Condition, error) {
+	v := ""
+	if value != nil {
+		v = *value
+	}
+
+	c := &Condition{Name: condition}
+	switch condition {
+	case ConditionAcknowledged:
+	case ConditionSeverity:
+		c.Severity = AlertSeverity(v)
+		if !c.Severity.valid() {
+			return nil, fmt.Errorf("unknown severity %q", v)
+		}
+	case ConditionMetadataMatches:
+		key, pattern, ok := strings.Cut(v, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("metadata condition %q is not of the form key=pattern", v)
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("metadata condition %q: %v", v, err)
+		}
+		c.MetadataKey, c.MetadataPattern = key, re
+	case ConditionTimeOfDay:
+		if err := c.parseTimeWindow(v); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown condition %q", condition)
+	}
+	return c, nil
+}
+
+func (c *Condition) parseTimeWindow(value string) error {
+	window, zone, _ := strings.Cut(strings.TrimSpace(value), " ")
+	from, to, ok := strings.Cut(window, "-")
+	if !ok {
+		return fmt.Errorf("time window %q is not of the form HH:MM-HH:MM", value)
+	}
+
+	c.Location = time.UTC
+	if zone = strings.TrimSpace(zone); zone != "" {
+		loc, err := time.LoadLocation(zone)
+		if err != nil {
+			return fmt.Errorf("time window %q: %v", value, err)
+		}
+		c.Location = loc
+	}
+
+	var err error
+	if c.Start, err = ParseTimeOfDay(from); err != nil {
+		return fmt.Errorf("time window %q: %v", value, err)
+	}
+	if c.End, err = ParseTimeOfDay(to); err != nil {
+		return fmt.Errorf("time window %q: %v", value, err)
+	}
+	return nil
+}
+
+// Evaluate decides the branch a conditional rule with this condition takes
+// for alert at the given time.
+func (c *Condition) Evaluate(alert Alert, at time.Time) bool {
+	switch c.Name {
+	case ConditionAcknowledged:
+		return alert.AcknowledgedAt != nil
+	case ConditionSeverity:
+		return alert.Severity == c.Severity
+	case ConditionMetadataMatches:
+		v, ok := alert.Metadata[c.MetadataKey]
+		return ok && c.MetadataPattern.MatchString(templateText(v))
+	case ConditionTimeOfDay:
+		local := at.In(c.Location)
+		minute := local.Hour()*60 + local.Minute()
+		start, end := c.Start.Minutes(), c.End.Minutes()
+		if start <= end {
+			return minute >= start && minute < end
+		}
+		return minute >= start || minute < end
+	}
+	return false
+}
+
+// Evaluate parses the condition with ParseCondition and evaluates it for
+// alert at the given time.
+func (c ConditionalConfig) Evaluate(alert Alert, at time.Time) (bool, error) {
+	cond, err := ParseCondition(c.Condition, c.ConditionValue)
+	if err != nil {
+		return false, err
+	}
+	return cond.Evaluate(alert, at), nil
+}
@@ -0,0 +1,113 @@
+package oncall
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestConditionEvaluate(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	acked := time.Date(2026, 1, 6, 3, 0, 0, 0, time.UTC)
+	alert := Alert{
+		Severity: SeverityCritical,
+		Metadata: map[string]any{"region": "eu-west-1", "replicas": float64(3)},
+	}
+	noon := time.Date(2026, 1, 6, 12, 0, 0, 0, time.UTC)
+	nineAM, fivePM := TimeOfDay{Hour: 9}, TimeOfDay{Hour: 17}
+	night := time.Date(2026, 1, 6, 23, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		cfg  ConditionalConfig
+		at   time.Time
+		want bool
+	}{
+		{"not acknowledged", IfAcknowledged(), noon, false},
+		{"severity matches", IfSeverity(SeverityCritical), noon, true},
+		{"severity differs", IfSeverity(SeverityLow), noon, false},
+		{"metadata matches", IfMetadataMatches("region", "^eu-"), noon, true},
+		{"metadata number", IfMetadataMatches("replicas", "^3$"), noon, true},
+		{"metadata missing", IfMetadataMatches("zone", ".*"), noon, false},
+		{"inside window", IfTimeOfDay(nineAM, fivePM, nil), noon, true},
+		{"outside window", IfTimeOfDay(nineAM, fivePM, nil), night, false},
+		{"window across midnight", IfTimeOfDay(TimeOfDay{Hour: 22}, TimeOfDay{Hour: 6}, nil), night, true},
+		{"window in time zone", IfTimeOfDay(nineAM, TimeOfDay{Hour: 12, Minute: 30}, berlin), noon, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.cfg.Evaluate(alert, tt.at)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	alert.AcknowledgedAt = &acked
+	if ok, _ := IfAcknowledged().Evaluate(alert, noon); !ok {
+		t.Fatal("expected acknowledged alert to take the true branch")
+	}
+	if _, err := (ConditionalConfig{Condition: "custom"}).Evaluate(alert, noon); err == nil {
+		t.Fatal("expected error for unknown condition")
+	}
+}
+
+func TestConditionSerialization(t *testing.T) {
+	b, err := json.Marshal(IfTimeOfDay(TimeOfDay{Hour: 22}, TimeOfDay{Hour: 6}, nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(b) != `{"condition":"time_of_day","conditionValue":"22:00-06:00"}` {
+		t.Fatalf("unexpected serialization: %s", b)
+	}
+
+	b, _ = json.Marshal(IfMetadataMatches("service", "^checkout$"))
+	if string(b) != `{"condition":"metadata_matches","conditionValue":"service=^checkout$"}` {
+		t.Fatalf("unexpected serialization: %s", b)
+	}
+
+	// Values outside the client's grammar are left to the server.
+	for _, cfg := range []ConditionalConfig{IfSeverity("urgent"), IfTimeOfDay(TimeOfDay{Hour: 25}, TimeOfDay{Hour: 17}, nil)} {
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("expected %s %q to pass validation, got %v", cfg.Condition, *cfg.ConditionValue, err)
+		}
+	}
+}
+
+func TestParseCondition(t *testing.T) {
+	value := "09:00-17:30 UTC"
+	cond, err := ParseCondition(ConditionTimeOfDay, &value)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cond.Start != (TimeOfDay{Hour: 9}) || cond.End != (TimeOfDay{Hour: 17, Minute: 30}) || cond.Location != time.UTC {
+		t.Fatalf("unexpected condition %+v", cond)
+	}
+
+	value = "service=^checkout$"
+	cond, err = ParseCondition(ConditionMetadataMatches, &value)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cond.MetadataKey != "service" || !cond.MetadataPattern.MatchString("checkout") {
+		t.Fatalf("unexpected condition %+v", cond)
+	}
+
+	invalid := map[string]string{
+		ConditionSeverity:        "urgent",
+		ConditionMetadataMatches: "(",
+		ConditionTimeOfDay:       "25:00-17:00",
+		"custom":                 "",
+	}
+	for condition, value := range invalid {
+		if _, err := ParseCondition(condition, &value); err == nil {
+			t.Errorf("expected error parsing %s %q", condition, value)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+// Package configsync keeps oncall.sh relays, rules and schedules in line with
+// a declarative YAML or JSON document, and exports live organizations to the
+// same format.
+//
+// Resources are matched to the document by their ExternalKey. Inside rule
+// configs, scheduleId, trueRuleId, falseRuleId and resetToRuleId may name a
+// schedule or rule by key instead of by ID; keys are translated to IDs when
+// the document is applied.
+package configsync
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/oncall-sh/oncall-go"
+	"gopkg.in/yaml.v3"
+)
+
+const CurrentVersion = 1
+
+type Document struct {
+	Version   int        `json:"version" yaml:"version"`
+	Relays    []Relay    `json:"relays,omitempty" yaml:"relays,omitempty"`
+	Schedules []Schedule `json:"schedules,omitempty" yaml:"schedules,omitempty"`
+
+	// Integrations and ContactMethods are recorded by Export for backups and
+	// comparisons. Sync does not manage them.
+	Integrations   []Integration   `json:"integrations,omitempty" yaml:"integrations,omitempty"`
+	ContactMethods []ContactMethod `json:"contactMethods,omitempty" yaml:"contactMethods,omitempty"`
+}
+
+type Relay struct {
+	Key         string `json:"key" yaml:"key"`
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Rules       []Rule `json:"rules,omitempty" yaml:"rules,omitempty"`
+}
+
+// Rule is a relay rule. Its position in Relay.Rules determines its Order.
+type Rule struct {
+	Key     string               `json:"key" yaml:"key"`
+	Name    string               `json:"name" yaml:"name"`
+	Type    oncall.RelayRuleType `json:"type" yaml:"type"`
+	Group   string               `json:"group,omitempty" yaml:"group,omitempty"`
+	Enabled *bool                `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	Config  map[string]any       `json:"config" yaml:"config"`
+}
+
+func (r Rule) enabled() bool {
+	return r.Enabled == nil || *r.Enabled
+}
+
+type Schedule struct {
+	Key       string              `json:"key" yaml:"key"`
+	Name      string              `json:"name" yaml:"name"`
+	Relay     string              `json:"relay" yaml:"relay"`
+	Type      oncall.ScheduleType `json:"type" yaml:"type"`
+	StartDay  oncall.DayOfWeek    `json:"startDay" yaml:"startDay"`
+	StartTime oncall.TimeOfDay    `json:"startTime" yaml:"startTime"`
+	// Members lists user IDs in rotation order.
+	Members []string `json:"members,omitempty" yaml:"members,omitempty"`
+}
+
+// RedactedSecret stands in for secrets in exported documents, so that they
+// can be committed without leaking any part of the secret.
+const RedactedSecret = "<redacted>"
+
+type Integration struct {
+	Name     string                     `json:"name" yaml:"name"`
+	Provider oncall.IntegrationProvider `json:"provider" yaml:"provider"`
+	// APIKey is RedactedSecret on export.
+	APIKey   string         `json:"apiKey" yaml:"apiKey"`
+	Metadata map[string]any `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+}
+
+type ContactMethod struct {
+	UserID    string `json:"userId" yaml:"userId"`
+	Transport string `json:"transport" yaml:"transport"`
+	Value     string `json:"value" yaml:"value"`
+	Verified  bool   `json:"verified" yaml:"verified"`
+}
+
+// Load reads a document in YAML or JSON.
+func Load(r io.Reader) (*Document, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc Document
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		err = json.Unmarshal(data, &doc)
+	} else {
+		err = yaml.Unmarshal(data, &doc)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse document: %w", err)
+	}
+	if err := doc.Validate(); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+func LoadFile(path string) (*Document, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Load(f)
+}
+
+// Validate checks that the document is well formed: a supported version,
+// keys present and unique, and schedules pointing at relays in the document.
+func (d *Document) Validate() error {
+	if d.Version != CurrentVersion {
+		return fmt.Errorf("unsupported document version %d (expected %d)", d.Version, CurrentVersion)
+	}
+
+	relays := make(map[string]bool)
+	for i, relay := range d.Relays {
+		if relay.Key == "" {
+			return fmt.Errorf("relays[%d]: key is required", i)
+		}
+		if relays[relay.Key] {
+			return fmt.Errorf("relays[%d]: duplicate key %q", i, relay.Key)
+		}
+		relays[relay.Key] = true
+
+		rules := make(map[string]bool)
+		for j, rule := range relay.Rules {
+			if rule.Key == "" {
+				return fmt.Errorf("relays[%d].rules[%d]: key is required", i, j)
+			}
+			if rules[rule.Key] {
+				return fmt.Errorf("relays[%d].rules[%d]: duplicate key %q", i, j, rule.Key)
+			}
+			rules[rule.Key] = true
+		}
+	}
+
+	schedules := make(map[string]bool)
+	for i, schedule := range d.Schedules {
+		if schedule.Key == "" {
+			return fmt.Errorf("schedules[%d]: key is required", i)
+		}
+		if schedules[schedule.Key] {
+			return fmt.Errorf("schedules[%d]: duplicate key %q", i, schedule.Key)
+		}
+		schedules[schedule.Key] = true
+		if !relays[schedule.Relay] {
+			return fmt.Errorf("schedules[%d]: relay %q is not defined in the document", i, schedule.Relay)
+		}
+	}
+	return nil
+}
+
+// WriteYAML encodes the document as YAML.
+func (d *Document) WriteYAML(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(d); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// WriteJSON encodes the document as indented JSON.
+func (d *Document) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(d)
+}
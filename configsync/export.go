@@ -0,0 +1,218 @@
+package configsync
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/oncall-sh/oncall-go"
+)
+
+// Export snapshots everything the client can see into a document: relays and
+// their rules, schedules and members, integrations and contact methods.
+//
+// Resources are keyed by their ExternalKey. Resources without one get a key
+// derived from their name; applying the document with Sync will then create
+// new, keyed copies rather than adopt the originals. Schedule and rule IDs in
+// rule configs are replaced by keys, and integration API keys are replaced by RedactedSecret.
+// The output is sorted so that exports of two organizations can be diffed.
+func (s *Syncer) Export(ctx context.Context) (*Document, error) {
+	doc := &Document{Version: CurrentVersion}
+
+	relays, err := s.client.Relay.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	schedules, err := s.client.Schedule.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	relayKey := make(map[string]string)
+	keys := assignKeys(relays, func(r oncall.Relay) *string { return r.ExternalKey }, func(r oncall.Relay) string { return r.Name })
+	for i, relay := range relays {
+		relayKey[relay.ID] = keys[i]
+	}
+	scheduleKey := make(map[string]string)
+	keys = assignKeys(schedules, func(s oncall.Schedule) *string { return s.ExternalKey }, func(s oncall.Schedule) string { return s.Name })
+	for i, schedule := range schedules {
+		scheduleKey[schedule.ID] = keys[i]
+	}
+
+	for _, relay := range relays {
+		rules, err := s.client.Relay.Rules.List(ctx, relay.ID, nil)
+		if err != nil {
+			return nil, err
+		}
+		slices.SortStableFunc(rules, func(a, b oncall.RelayRule) int { return a.Order - b.Order })
+
+		ruleKey := make(map[string]string)
+		keys := assignKeys(rules, func(r oncall.RelayRule) *string { return r.ExternalKey }, func(r oncall.RelayRule) string { return r.Name })
+		for i, rule := range rules {
+			ruleKey[rule.ID] = keys[i]
+		}
+
+		out := Relay{Key: relayKey[relay.ID], Name: relay.Name, Description: relay.Description}
+		for _, rule := range rules {
+			r := Rule{
+				Key:    ruleKey[rule.ID],
+				Name:   rule.Name,
+				Type:   rule.RuleType,
+				Group:  rule.Group,
+				Config: documentConfig(rule.Config, scheduleKey, ruleKey),
+			}
+			if !rule.Enabled {
+				disabled := false
+				r.Enabled = &disabled
+			}
+			out.Rules = append(out.Rules, r)
+		}
+		doc.Relays = append(doc.Relays, out)
+	}
+
+	for _, schedule := range schedules {
+		key, ok := relayKey[schedule.RelayID]
+		if !ok {
+			return nil, fmt.Errorf("schedule %s belongs to relay %s, which is not visible", schedule.ID, schedule.RelayID)
+		}
+		members, err := s.client.Schedule.ListMembers(ctx, schedule.ID)
+		if err != nil {
+			return nil, err
+		}
+		slices.SortStableFunc(members, func(a, b oncall.ScheduleMember) int { return a.Order - b.Order })
+
+		out := Schedule{
+			Key:       scheduleKey[schedule.ID],
+			Name:      schedule.Name,
+			Relay:     key,
+			Type:      schedule.Type,
+			StartDay:  schedule.StartDay,
+			StartTime: schedule.StartTime,
+		}
+		for _, m := range members {
+			out.Members = append(out.Members, m.UserID)
+		}
+		doc.Schedules = append(doc.Schedules, out)
+	}
+
+	integrations, err := s.client.Integration.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, integration := range integrations {
+		doc.Integrations = append(doc.Integrations, Integration{
+			Name:     integration.Name,
+			Provider: integration.Provider,
+			APIKey:   redactSecret(integration.APIKey),
+			Metadata: integration.Metadata,
+		})
+	}
+
+	users, err := s.client.User.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		methods, err := s.client.ContactMethod.List(ctx, oncall.ListContactMethodsParams{UserID: user.ID})
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range methods {
+			doc.ContactMethods = append(doc.ContactMethods, ContactMethod{
+				UserID:    m.UserID,
+				Transport: m.Transport,
+				Value:     m.Value,
+				Verified:  m.Verified,
+			})
+		}
+	}
+
+	doc.sort()
+	return doc, nil
+}
+
+func (d *Document) sort() {
+	slices.SortStableFunc(d.Relays, func(a, b Relay) int { return strings.Compare(a.Key, b.Key) })
+	slices.SortStableFunc(d.Schedules, func(a, b Schedule) int { return strings.Compare(a.Key, b.Key) })
+	slices.SortStableFunc(d.Integrations, func(a, b Integration) int {
+		if c := strings.Compare(string(a.Provider), string(b.Provider)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	slices.SortStableFunc(d.ContactMethods, func(a, b ContactMethod) int {
+		if c := strings.Compare(a.UserID, b.UserID); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.Transport, b.Transport); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Value, b.Value)
+	})
+}
+
+// documentConfig is the inverse of resolver.apiConfig: schedule and rule IDs
+// are replaced by their keys. IDs that are not known are kept.
+func documentConfig(config map[string]any, scheduleKey, ruleKey map[string]string) map[string]any {
+	if config == nil {
+		return nil
+	}
+	out := make(map[string]any, len(config))
+	for k, v := range config {
+		out[k] = v
+	}
+	if id, ok := out["scheduleId"].(string); ok {
+		if key, ok := scheduleKey[id]; ok {
+			out["scheduleId"] = key
+		}
+	}
+	for _, field := range ruleReferenceFields {
+		if id, ok := out[field].(string); ok {
+			if key, ok := ruleKey[id]; ok {
+				out[field] = key
+			}
+		}
+	}
+	return out
+}
+
+var nonKeyChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// assignKeys returns a unique key for each item, aligned with items. Items
+// keep their ExternalKey; the rest get a key derived from their name.
+func assignKeys[T any](items []T, externalKey func(T) *string, name func(T) string) []string {
+	keys := make([]string, len(items))
+	used := make(map[string]bool)
+	for i, item := range items {
+		if key := externalKey(item); key != nil && *key != "" && !used[*key] {
+			keys[i] = *key
+			used[*key] = true
+		}
+	}
+	for i, item := range items {
+		if keys[i] != "" {
+			continue
+		}
+		base := strings.Trim(nonKeyChars.ReplaceAllString(strings.ToLower(name(item)), "-"), "-")
+		if base == "" {
+			base = "unnamed"
+		}
+		key := base
+		for n := 2; used[key]; n++ {
+			key = fmt.Sprintf("%s-%d", base, n)
+		}
+		keys[i] = key
+		used[key] = true
+	}
+	return keys
+}
+
+// redactSecret replaces a secret with RedactedSecret, keeping no part of it.
+func redactSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return RedactedSecret
+}
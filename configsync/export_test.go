@@ -0,0 +1,97 @@
+package configsync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/oncall-sh/oncall-go"
+)
+
+func TestExport(t *testing.T) {
+	doc, err := Load(strings.NewReader(testDocument))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	api := &fakeAPI{
+		integrations: []oncall.Integration{{ID: "int1", Name: "Devin", Provider: oncall.ProviderDevin, APIKey: "sk-live-1234567890abcd"}},
+		users:        []oncall.User{{ID: "alice"}, {ID: "bob"}},
+		contactMethods: []oncall.ContactMethod{
+			{ID: "cm2", UserID: "bob", Transport: "email", Value: "bob@example.com"},
+			{ID: "cm1", UserID: "alice", Transport: "sms", Value: "+15555550100", Verified: true},
+		},
+	}
+	syncer := newTestSyncer(t, api)
+	ctx := context.Background()
+	if _, err := syncer.Sync(ctx, doc, Options{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	exported, err := syncer.Export(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rules := exported.Relays[0].Rules
+	if rules[0].Config["scheduleId"] != "primary" || rules[1].Config["trueRuleId"] != "done" || rules[2].Config["resetToRuleId"] != "page" {
+		t.Fatalf("IDs not translated to keys: %+v", rules)
+	}
+	if exported.Integrations[0].APIKey != RedactedSecret {
+		t.Fatalf("expected redacted API key, got %q", exported.Integrations[0].APIKey)
+	}
+	if len(exported.ContactMethods) != 2 || exported.ContactMethods[0].UserID != "alice" {
+		t.Fatalf("unexpected contact methods: %+v", exported.ContactMethods)
+	}
+
+	var buf bytes.Buffer
+	if err := exported.WriteYAML(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(buf.String(), "abcd") {
+		t.Fatalf("exported YAML contains part of the API key:\n%s", buf.String())
+	}
+	reloaded, err := Load(&buf)
+	if err != nil {
+		t.Fatalf("exported document does not load: %v", err)
+	}
+	want, _ := json.Marshal(exported)
+	got, _ := json.Marshal(reloaded)
+	if !bytes.Equal(want, got) {
+		t.Fatalf("document did not round-trip:\nwant %s\ngot  %s", want, got)
+	}
+
+	plan, err := syncer.Plan(ctx, reloaded, Options{Prune: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !plan.Empty() {
+		t.Fatalf("expected exported document to match the organization, got:\n%s", plan)
+	}
+}
+
+func TestExportDerivesKeys(t *testing.T) {
+	key := "ops"
+	api := &fakeAPI{
+		relays: []oncall.Relay{
+			{ID: "r1", Name: "Ops"},
+			{ID: "r2", Name: "Ops", ExternalKey: &key},
+			{ID: "r3", Name: "Ops!"},
+			{ID: "r4", Name: "  "},
+		},
+	}
+	exported, err := newTestSyncer(t, api).Export(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var keys []string
+	for _, relay := range exported.Relays {
+		keys = append(keys, relay.Key)
+	}
+	if strings.Join(keys, ",") != "ops,ops-2,ops-3,unnamed" {
+		t.Fatalf("unexpected keys: %v", keys)
+	}
+	if err := exported.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
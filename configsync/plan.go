@@ -0,0 +1,111 @@
+package configsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type ActionKind string
+
+const (
+	ActionCreate  ActionKind = "create"
+	ActionUpdate  ActionKind = "update"
+	ActionDelete  ActionKind = "delete"
+	ActionReorder ActionKind = "reorder"
+)
+
+type ResourceKind string
+
+const (
+	ResourceRelay    ResourceKind = "relay"
+	ResourceRule     ResourceKind = "rule"
+	ResourceSchedule ResourceKind = "schedule"
+	ResourceMembers  ResourceKind = "members"
+)
+
+type Action struct {
+	Kind     ActionKind
+	Resource ResourceKind
+	// Key identifies the resource: "<relay>" for relays, "<relay>/<rule>"
+	// for rules and "<schedule>" for schedules and their members.
+	Key     string
+	Changes []string
+
+	run func(ctx context.Context) error
+}
+
+func (a Action) String() string {
+	symbol := map[ActionKind]string{
+		ActionCreate:  "+",
+		ActionUpdate:  "~",
+		ActionDelete:  "-",
+		ActionReorder: ">",
+	}[a.Kind]
+	return fmt.Sprintf("%s %s %s %s", symbol, a.Kind, a.Resource, a.Key)
+}
+
+type Plan struct {
+	Actions []Action
+
+	state   *resolver
+	fixups  []func(ctx context.Context) error
+	applied bool
+}
+
+func (p *Plan) Empty() bool {
+	return len(p.Actions) == 0
+}
+
+// String renders the plan for humans, one action per line followed by its
+// field changes and a summary.
+func (p *Plan) String() string {
+	if p.Empty() {
+		return "No changes.\n"
+	}
+
+	var b strings.Builder
+	counts := make(map[ActionKind]int)
+	for _, a := range p.Actions {
+		counts[a.Kind]++
+		b.WriteString(a.String())
+		b.WriteString("\n")
+		for _, change := range a.Changes {
+			fmt.Fprintf(&b, "    %s\n", change)
+		}
+	}
+	fmt.Fprintf(&b, "\nPlan: %d to create, %d to update, %d to delete, %d to reorder.\n",
+		counts[ActionCreate], counts[ActionUpdate], counts[ActionDelete], counts[ActionReorder])
+	return b.String()
+}
+
+func (p *Plan) add(a Action) {
+	p.Actions = append(p.Actions, a)
+}
+
+// afterActions schedules fn to run once every action has been applied.
+func (p *Plan) afterActions(fn func(ctx context.Context) error) {
+	p.fixups = append(p.fixups, fn)
+}
+
+// Apply runs the plan's actions in order and stops at the first failure. A
+// plan can only be applied once.
+func (p *Plan) Apply(ctx context.Context) error {
+	if p.applied {
+		return errors.New("plan has already been applied")
+	}
+	p.applied = true
+
+	for _, a := range p.Actions {
+		if err := a.run(ctx); err != nil {
+			return fmt.Errorf("%s %s %s: %w", a.Kind, a.Resource, a.Key, err)
+		}
+	}
+	for _, fixup := range p.fixups {
+		if err := fixup(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
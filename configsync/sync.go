@@ -0,0 +1,596 @@
+package configsync
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/oncall-sh/oncall-go"
+)
+
+type Options struct {
+	// Prune deletes relays, schedules and rules that carry an ExternalKey but
+	// are missing from the document. Resources without a key are never
+	// touched.
+	Prune bool
+	// DryRun makes Sync return the plan without applying it.
+	DryRun bool
+}
+
+type Syncer struct {
+	client *oncall.Client
+}
+
+func New(client *oncall.Client) *Syncer {
+	return &Syncer{client: client}
+}
+
+// Sync plans the changes needed to make the live organization match doc and,
+// unless opts.DryRun is set, applies them.
+func (s *Syncer) Sync(ctx context.Context, doc *Document, opts Options) (*Plan, error) {
+	plan, err := s.Plan(ctx, doc, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.DryRun {
+		return plan, nil
+	}
+	return plan, plan.Apply(ctx)
+}
+
+// liveState is what the API key can currently see, indexed by ExternalKey.
+type liveState struct {
+	relays    map[string]oncall.Relay
+	rules     map[string][]oncall.RelayRule
+	schedules map[string]oncall.Schedule
+	members   map[string][]oncall.ScheduleMember
+}
+
+func (s *Syncer) fetch(ctx context.Context) (*liveState, error) {
+	live := &liveState{
+		relays:    make(map[string]oncall.Relay),
+		rules:     make(map[string][]oncall.RelayRule),
+		schedules: make(map[string]oncall.Schedule),
+		members:   make(map[string][]oncall.ScheduleMember),
+	}
+
+	relays, err := s.client.Relay.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, relay := range relays {
+		if relay.ExternalKey == nil {
+			continue
+		}
+		live.relays[*relay.ExternalKey] = relay
+		rules, err := s.client.Relay.Rules.List(ctx, relay.ID, nil)
+		if err != nil {
+			return nil, err
+		}
+		slices.SortStableFunc(rules, func(a, b oncall.RelayRule) int { return a.Order - b.Order })
+		live.rules[*relay.ExternalKey] = rules
+	}
+
+	schedules, err := s.client.Schedule.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, schedule := range schedules {
+		if schedule.ExternalKey == nil {
+			continue
+		}
+		live.schedules[*schedule.ExternalKey] = schedule
+		members, err := s.client.Schedule.ListMembers(ctx, schedule.ID)
+		if err != nil {
+			return nil, err
+		}
+		slices.SortStableFunc(members, func(a, b oncall.ScheduleMember) int { return a.Order - b.Order })
+		live.members[*schedule.ExternalKey] = members
+	}
+
+	return live, nil
+}
+
+// resolver maps document keys to live IDs. It starts from the live state and
+// learns the IDs of resources as they are created.
+type resolver struct {
+	relays    map[string]string
+	schedules map[string]string
+	rules     map[string]map[string]string
+}
+
+func newResolver(live *liveState) *resolver {
+	r := &resolver{
+		relays:    make(map[string]string),
+		schedules: make(map[string]string),
+		rules:     make(map[string]map[string]string),
+	}
+	for key, relay := range live.relays {
+		r.relays[key] = relay.ID
+		r.rules[key] = make(map[string]string)
+		for _, rule := range live.rules[key] {
+			if rule.ExternalKey != nil {
+				r.rules[key][*rule.ExternalKey] = rule.ID
+			}
+		}
+	}
+	for key, schedule := range live.schedules {
+		r.schedules[key] = schedule.ID
+	}
+	return r
+}
+
+func (r *resolver) setRule(relayKey, ruleKey, id string) {
+	if r.rules[relayKey] == nil {
+		r.rules[relayKey] = make(map[string]string)
+	}
+	r.rules[relayKey][ruleKey] = id
+}
+
+var ruleReferenceFields = []string{"trueRuleId", "falseRuleId", "resetToRuleId"}
+
+// apiConfig translates the schedule and rule keys used in a document config
+// to IDs. Values that are not keys of the document are passed through
+// unchanged. It reports whether every key could be resolved.
+func (r *resolver) apiConfig(doc *Document, relayKey string, config map[string]any) (map[string]any, bool) {
+	out := make(map[string]any, len(config))
+	for k, v := range config {
+		out[k] = v
+	}
+	resolved := true
+
+	if key, ok := out["scheduleId"].(string); ok && doc.hasSchedule(key) {
+		if id, ok := r.schedules[key]; ok {
+			out["scheduleId"] = id
+		} else {
+			resolved = false
+		}
+	}
+	for _, field := range ruleReferenceFields {
+		key, ok := out[field].(string)
+		if !ok || !doc.hasRule(relayKey, key) {
+			continue
+		}
+		if id, ok := r.rules[relayKey][key]; ok {
+			out[field] = id
+		} else {
+			delete(out, field)
+			resolved = false
+		}
+	}
+	return out, resolved
+}
+
+func (d *Document) hasSchedule(key string) bool {
+	return slices.ContainsFunc(d.Schedules, func(s Schedule) bool { return s.Key == key })
+}
+
+func (d *Document) hasRule(relayKey, ruleKey string) bool {
+	for _, relay := range d.Relays {
+		if relay.Key == relayKey {
+			return slices.ContainsFunc(relay.Rules, func(r Rule) bool { return r.Key == ruleKey })
+		}
+	}
+	return false
+}
+
+// Plan compares doc with the live organization and returns the actions that
+// would reconcile them, in the order they are applied: relays, schedules,
+// members, rules, rule order and finally deletions.
+func (s *Syncer) Plan(ctx context.Context, doc *Document, opts Options) (*Plan, error) {
+	if err := doc.Validate(); err != nil {
+		return nil, err
+	}
+	live, err := s.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{state: newResolver(live)}
+	for _, relay := range doc.Relays {
+		s.planRelay(plan, relay, live)
+	}
+	for _, schedule := range doc.Schedules {
+		if err := s.planSchedule(plan, schedule, live); err != nil {
+			return nil, err
+		}
+	}
+	for _, schedule := range doc.Schedules {
+		s.planMembers(plan, schedule, live)
+	}
+	for _, relay := range doc.Relays {
+		s.planRules(plan, doc, relay, live)
+	}
+	for _, relay := range doc.Relays {
+		s.planRuleOrder(plan, relay, live)
+	}
+	if opts.Prune {
+		s.planPrune(plan, doc, live)
+	}
+	return plan, nil
+}
+
+func (s *Syncer) planRelay(plan *Plan, relay Relay, live *liveState) {
+	current, exists := live.relays[relay.Key]
+	if !exists {
+		plan.add(Action{
+			Kind:     ActionCreate,
+			Resource: ResourceRelay,
+			Key:      relay.Key,
+			Changes:  []string{fmt.Sprintf("name: %q", relay.Name)},
+			run: func(ctx context.Context) error {
+				key := relay.Key
+				input := oncall.CreateRelayInput{Name: relay.Name, ExternalKey: &key}
+				if relay.Description != "" {
+					input.Description = &relay.Description
+				}
+				created, err := s.client.Relay.Create(ctx, input)
+				if err != nil {
+					return err
+				}
+				plan.state.relays[relay.Key] = created.ID
+				return nil
+			},
+		})
+		return
+	}
+
+	var input oncall.UpdateRelayInput
+	var changes []string
+	if current.Name != relay.Name {
+		input.Name = &relay.Name
+		changes = append(changes, fmt.Sprintf("name: %q -> %q", current.Name, relay.Name))
+	}
+	if current.Description != relay.Description {
+		input.Description = &relay.Description
+		changes = append(changes, fmt.Sprintf("description: %q -> %q", current.Description, relay.Description))
+	}
+	if len(changes) == 0 {
+		return
+	}
+	plan.add(Action{
+		Kind:     ActionUpdate,
+		Resource: ResourceRelay,
+		Key:      relay.Key,
+		Changes:  changes,
+		run: func(ctx context.Context) error {
+			_, err := s.client.Relay.Update(ctx, current.ID, input)
+			return err
+		},
+	})
+}
+
+func (s *Syncer) planSchedule(plan *Plan, schedule Schedule, live *liveState) error {
+	current, exists := live.schedules[schedule.Key]
+	if !exists {
+		plan.add(Action{
+			Kind:     ActionCreate,
+			Resource: ResourceSchedule,
+			Key:      schedule.Key,
+			Changes: []string{
+				fmt.Sprintf("name: %q", schedule.Name),
+				fmt.Sprintf("relay: %s", schedule.Relay),
+				fmt.Sprintf("rotation: %s from %s %s", schedule.Type, schedule.StartDay, schedule.StartTime),
+			},
+			run: func(ctx context.Context) error {
+				key := schedule.Key
+				created, err := s.client.Schedule.Create(ctx, oncall.CreateScheduleInput{
+					Name:        schedule.Name,
+					RelayID:     plan.state.relays[schedule.Relay],
+					Type:        schedule.Type,
+					StartDay:    schedule.StartDay,
+					StartTime:   schedule.StartTime,
+					ExternalKey: &key,
+				})
+				if err != nil {
+					return err
+				}
+				plan.state.schedules[schedule.Key] = created.ID
+				return nil
+			},
+		})
+		return nil
+	}
+
+	if relayID, ok := plan.state.relays[schedule.Relay]; !ok || relayID != current.RelayID {
+		return fmt.Errorf("schedule %q: moving a schedule to another relay is not supported", schedule.Key)
+	}
+
+	var input oncall.UpdateScheduleInput
+	var changes []string
+	if current.Name != schedule.Name {
+		input.Name = &schedule.Name
+		changes = append(changes, fmt.Sprintf("name: %q -> %q", current.Name, schedule.Name))
+	}
+	if current.Type != schedule.Type {
+		input.Type = &schedule.Type
+		changes = append(changes, fmt.Sprintf("type: %s -> %s", current.Type, schedule.Type))
+	}
+	if current.StartDay != schedule.StartDay {
+		input.StartDay = &schedule.StartDay
+		changes = append(changes, fmt.Sprintf("startDay: %s -> %s", current.StartDay, schedule.StartDay))
+	}
+	if current.StartTime != schedule.StartTime {
+		input.StartTime = &schedule.StartTime
+		changes = append(changes, fmt.Sprintf("startTime: %s -> %s", current.StartTime, schedule.StartTime))
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	plan.add(Action{
+		Kind:     ActionUpdate,
+		Resource: ResourceSchedule,
+		Key:      schedule.Key,
+		Changes:  changes,
+		run: func(ctx context.Context) error {
+			_, err := s.client.Schedule.Update(ctx, current.ID, input)
+			return err
+		},
+	})
+	return nil
+}
+
+func (s *Syncer) planMembers(plan *Plan, schedule Schedule, live *liveState) {
+	var current []string
+	for _, m := range live.members[schedule.Key] {
+		current = append(current, m.UserID)
+	}
+	if slices.Equal(current, schedule.Members) {
+		return
+	}
+
+	var added, removed, changes []string
+	for _, userID := range schedule.Members {
+		if !slices.Contains(current, userID) {
+			added = append(added, userID)
+			changes = append(changes, "+ "+userID)
+		}
+	}
+	for _, userID := range current {
+		if !slices.Contains(schedule.Members, userID) {
+			removed = append(removed, userID)
+			changes = append(changes, "- "+userID)
+		}
+	}
+
+	// After adding and removing, members that are kept stay in their current
+	// order and new ones are appended; reorder only if that is not the goal.
+	after := slices.DeleteFunc(slices.Clone(current), func(id string) bool { return slices.Contains(removed, id) })
+	after = append(after, added...)
+	reorder := !slices.Equal(after, schedule.Members)
+	if reorder {
+		changes = append(changes, fmt.Sprintf("order: %s -> %s", strings.Join(after, ", "), strings.Join(schedule.Members, ", ")))
+	}
+
+	kind := ActionUpdate
+	if len(current) == 0 {
+		kind = ActionCreate
+	}
+	plan.add(Action{
+		Kind:     kind,
+		Resource: ResourceMembers,
+		Key:      schedule.Key,
+		Changes:  changes,
+		run: func(ctx context.Context) error {
+			scheduleID := plan.state.schedules[schedule.Key]
+			for _, userID := range added {
+				if _, err := s.client.Schedule.AddMember(ctx, scheduleID, oncall.AddScheduleMemberInput{UserID: userID}); err != nil {
+					return err
+				}
+			}
+			for _, userID := range removed {
+				if err := s.client.Schedule.RemoveMember(ctx, scheduleID, userID); err != nil {
+					return err
+				}
+			}
+			if reorder {
+				_, err := s.client.Schedule.ReorderMembers(ctx, scheduleID, oncall.ReorderScheduleMembersInput{UserIDs: schedule.Members})
+				return err
+			}
+			return nil
+		},
+	})
+}
+
+func (s *Syncer) planRules(plan *Plan, doc *Document, relay Relay, live *liveState) {
+	current := make(map[string]oncall.RelayRule)
+	for _, rule := range live.rules[relay.Key] {
+		if rule.ExternalKey != nil {
+			current[*rule.ExternalKey] = rule
+		}
+	}
+
+	for i, rule := range relay.Rules {
+		order := i + 1
+		existing, exists := current[rule.Key]
+		if !exists {
+			plan.add(Action{
+				Kind:     ActionCreate,
+				Resource: ResourceRule,
+				Key:      relay.Key + "/" + rule.Key,
+				Changes:  []string{fmt.Sprintf("name: %q", rule.Name), fmt.Sprintf("type: %s", rule.Type), fmt.Sprintf("order: %d", order)},
+				run: func(ctx context.Context) error {
+					key := rule.Key
+					enabled := rule.enabled()
+					config, resolved := plan.state.apiConfig(doc, relay.Key, rule.Config)
+					input := oncall.CreateRelayRuleInput{
+						ExternalKey: &key,
+						Name:        rule.Name,
+						RuleType:    rule.Type,
+						Order:       &order,
+						Config:      config,
+						Enabled:     &enabled,
+					}
+					if rule.Group != "" {
+						input.Group = &rule.Group
+					}
+					created, err := s.client.Relay.Rules.Create(ctx, plan.state.relays[relay.Key], input)
+					if err != nil {
+						return err
+					}
+					plan.state.setRule(relay.Key, rule.Key, created.ID)
+					if !resolved {
+						plan.afterActions(s.resolveRuleReferences(plan, doc, relay.Key, created.ID, rule))
+					}
+					return nil
+				},
+			})
+			continue
+		}
+
+		var input oncall.UpdateRelayRuleInput
+		var changes []string
+		if existing.Name != rule.Name {
+			input.Name = &rule.Name
+			changes = append(changes, fmt.Sprintf("name: %q -> %q", existing.Name, rule.Name))
+		}
+		if existing.RuleType != rule.Type {
+			input.RuleType = &rule.Type
+			changes = append(changes, fmt.Sprintf("type: %s -> %s", existing.RuleType, rule.Type))
+		}
+		if existing.Group != rule.Group {
+			input.Group = &rule.Group
+			changes = append(changes, fmt.Sprintf("group: %q -> %q", existing.Group, rule.Group))
+		}
+		if existing.Enabled != rule.enabled() {
+			enabled := rule.enabled()
+			input.Enabled = &enabled
+			changes = append(changes, fmt.Sprintf("enabled: %t -> %t", existing.Enabled, enabled))
+		}
+		desired, _ := plan.state.apiConfig(doc, relay.Key, rule.Config)
+		configChanged := !oncall.RuleConfigEqual(existing.Config, desired)
+		if configChanged {
+			changes = append(changes, "config changed")
+		}
+		if len(changes) == 0 {
+			continue
+		}
+		plan.add(Action{
+			Kind:     ActionUpdate,
+			Resource: ResourceRule,
+			Key:      relay.Key + "/" + rule.Key,
+			Changes:  changes,
+			run: func(ctx context.Context) error {
+				if configChanged {
+					config, resolved := plan.state.apiConfig(doc, relay.Key, rule.Config)
+					input.Config = config
+					if input.RuleType == nil {
+						input.RuleType = &rule.Type
+					}
+					if !resolved {
+						plan.afterActions(s.resolveRuleReferences(plan, doc, relay.Key, existing.ID, rule))
+					}
+				}
+				_, err := s.client.Relay.Rules.Update(ctx, plan.state.relays[relay.Key], existing.ID, input)
+				return err
+			},
+		})
+	}
+}
+
+// resolveRuleReferences rewrites a rule's config once every rule it refers to
+// has been created.
+func (s *Syncer) resolveRuleReferences(plan *Plan, doc *Document, relayKey, ruleID string, rule Rule) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		config, resolved := plan.state.apiConfig(doc, relayKey, rule.Config)
+		if !resolved {
+			return fmt.Errorf("rule %s/%s: unresolved references in config", relayKey, rule.Key)
+		}
+		_, err := s.client.Relay.Rules.Update(ctx, plan.state.relays[relayKey], ruleID, oncall.UpdateRelayRuleInput{
+			RuleType: &rule.Type,
+			Config:   config,
+		})
+		return err
+	}
+}
+
+func (s *Syncer) planRuleOrder(plan *Plan, relay Relay, live *liveState) {
+	var changes []string
+	for i, rule := range relay.Rules {
+		for _, existing := range live.rules[relay.Key] {
+			if existing.ExternalKey != nil && *existing.ExternalKey == rule.Key && existing.Order != i+1 {
+				changes = append(changes, fmt.Sprintf("%s: %d -> %d", rule.Key, existing.Order, i+1))
+			}
+		}
+	}
+	if len(changes) == 0 {
+		return
+	}
+	plan.add(Action{
+		Kind:     ActionReorder,
+		Resource: ResourceRule,
+		Key:      relay.Key,
+		Changes:  changes,
+		run: func(ctx context.Context) error {
+			var input oncall.ReorderRelayRulesInput
+			for i, rule := range relay.Rules {
+				input.Rules = append(input.Rules, oncall.RuleOrder{ID: plan.state.rules[relay.Key][rule.Key], Order: i + 1})
+			}
+			_, err := s.client.Relay.Rules.Reorder(ctx, plan.state.relays[relay.Key], input)
+			return err
+		},
+	})
+}
+
+func (s *Syncer) planPrune(plan *Plan, doc *Document, live *liveState) {
+	inDoc := make(map[string]bool)
+	for _, relay := range doc.Relays {
+		inDoc[relay.Key] = true
+	}
+
+	for _, relay := range doc.Relays {
+		for _, rule := range live.rules[relay.Key] {
+			if rule.ExternalKey == nil || doc.hasRule(relay.Key, *rule.ExternalKey) {
+				continue
+			}
+			plan.add(Action{
+				Kind:     ActionDelete,
+				Resource: ResourceRule,
+				Key:      relay.Key + "/" + *rule.ExternalKey,
+				run: func(ctx context.Context) error {
+					return s.client.Relay.Rules.Delete(ctx, rule.RelayID, rule.ID)
+				},
+			})
+		}
+	}
+
+	for _, key := range sortedKeys(live.schedules) {
+		if doc.hasSchedule(key) {
+			continue
+		}
+		schedule := live.schedules[key]
+		plan.add(Action{
+			Kind:     ActionDelete,
+			Resource: ResourceSchedule,
+			Key:      key,
+			run: func(ctx context.Context) error {
+				return s.client.Schedule.Delete(ctx, schedule.ID)
+			},
+		})
+	}
+
+	for _, key := range sortedKeys(live.relays) {
+		if inDoc[key] {
+			continue
+		}
+		relay := live.relays[key]
+		plan.add(Action{
+			Kind:     ActionDelete,
+			Resource: ResourceRelay,
+			Key:      key,
+			run: func(ctx context.Context) error {
+				return s.client.Relay.Delete(ctx, relay.ID)
+			},
+		})
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,313 @@
+package configsync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/oncall-sh/oncall-go"
+)
+
+// fakeAPI is an in-memory stand-in for the endpoints used by the syncer and
+// the exporter.
+type fakeAPI struct {
+	mu             sync.Mutex
+	nextID         int
+	relays         []oncall.Relay
+	rules          []oncall.RelayRule
+	schedules      []oncall.Schedule
+	members        []oncall.ScheduleMember
+	integrations   []oncall.Integration
+	users          []oncall.User
+	contactMethods []oncall.ContactMethod
+	calls          []string
+}
+
+func (f *fakeAPI) id(prefix string) string {
+	f.nextID++
+	return fmt.Sprintf("%s%d", prefix, f.nextID)
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if r.Method != http.MethodGet {
+		f.calls = append(f.calls, r.Method+" "+r.URL.Path)
+	}
+	reply := func(v any) { json.NewEncoder(w).Encode(v) }
+	decode := func(v any) { json.NewDecoder(r.Body).Decode(v) }
+
+	switch {
+	case r.URL.Path == "/relay" && r.Method == http.MethodGet:
+		reply(map[string]any{"relays": f.relays})
+	case r.URL.Path == "/relay" && r.Method == http.MethodPost:
+		var in oncall.CreateRelayInput
+		decode(&in)
+		relay := oncall.Relay{ID: f.id("relay"), Name: in.Name, ExternalKey: in.ExternalKey}
+		if in.Description != nil {
+			relay.Description = *in.Description
+		}
+		f.relays = append(f.relays, relay)
+		reply(map[string]any{"relay": relay})
+	case len(parts) == 2 && parts[0] == "relay" && r.Method == http.MethodPut:
+		var in oncall.UpdateRelayInput
+		decode(&in)
+		for i := range f.relays {
+			if f.relays[i].ID == parts[1] {
+				if in.Name != nil {
+					f.relays[i].Name = *in.Name
+				}
+				if in.Description != nil {
+					f.relays[i].Description = *in.Description
+				}
+				reply(map[string]any{"relay": f.relays[i]})
+			}
+		}
+	case len(parts) == 2 && parts[0] == "relay" && r.Method == http.MethodDelete:
+		f.relays = slices.DeleteFunc(f.relays, func(x oncall.Relay) bool { return x.ID == parts[1] })
+		reply(map[string]any{"success": true})
+	case len(parts) == 3 && parts[2] == "rules" && r.Method == http.MethodGet:
+		var rules []oncall.RelayRule
+		for _, rule := range f.rules {
+			if rule.RelayID == parts[1] {
+				rules = append(rules, rule)
+			}
+		}
+		reply(map[string]any{"rules": rules})
+	case len(parts) == 3 && parts[2] == "rules" && r.Method == http.MethodPost:
+		var in oncall.CreateRelayRuleInput
+		decode(&in)
+		rule := oncall.RelayRule{
+			ID: f.id("rule"), RelayID: parts[1], Name: in.Name, RuleType: in.RuleType,
+			Config: in.Config, ExternalKey: in.ExternalKey, Enabled: *in.Enabled, Order: *in.Order,
+		}
+		if in.Group != nil {
+			rule.Group = *in.Group
+		}
+		f.rules = append(f.rules, rule)
+		reply(map[string]any{"rule": rule})
+	case len(parts) == 4 && parts[0] == "relay" && parts[3] == "reorder":
+		var in oncall.ReorderRelayRulesInput
+		decode(&in)
+		for _, change := range in.Rules {
+			for i := range f.rules {
+				if f.rules[i].ID == change.ID {
+					f.rules[i].Order = change.Order
+				}
+			}
+		}
+		reply(map[string]any{"rules": f.rules})
+	case len(parts) == 4 && parts[2] == "rules" && r.Method == http.MethodPut:
+		var in oncall.UpdateRelayRuleInput
+		decode(&in)
+		for i := range f.rules {
+			if f.rules[i].ID != parts[3] {
+				continue
+			}
+			if in.Name != nil {
+				f.rules[i].Name = *in.Name
+			}
+			if in.Config != nil {
+				f.rules[i].Config = in.Config
+			}
+			if in.Enabled != nil {
+				f.rules[i].Enabled = *in.Enabled
+			}
+			reply(map[string]any{"rule": f.rules[i]})
+		}
+	case len(parts) == 4 && parts[2] == "rules" && r.Method == http.MethodDelete:
+		f.rules = slices.DeleteFunc(f.rules, func(x oncall.RelayRule) bool { return x.ID == parts[3] })
+		reply(map[string]any{"success": true})
+	case r.URL.Path == "/schedule" && r.Method == http.MethodGet:
+		reply(map[string]any{"schedules": f.schedules})
+	case r.URL.Path == "/schedule" && r.Method == http.MethodPost:
+		var in oncall.CreateScheduleInput
+		decode(&in)
+		schedule := oncall.Schedule{
+			ID: f.id("sched"), RelayID: in.RelayID, Name: in.Name, Type: in.Type,
+			StartDay: in.StartDay, StartTime: in.StartTime, ExternalKey: in.ExternalKey,
+		}
+		f.schedules = append(f.schedules, schedule)
+		reply(map[string]any{"schedule": schedule})
+	case len(parts) == 3 && parts[2] == "members" && r.Method == http.MethodGet:
+		var members []oncall.ScheduleMember
+		for _, m := range f.members {
+			if m.ScheduleID == parts[1] {
+				members = append(members, m)
+			}
+		}
+		reply(map[string]any{"members": members})
+	case len(parts) == 3 && parts[2] == "members" && r.Method == http.MethodPost:
+		var in oncall.AddScheduleMemberInput
+		decode(&in)
+		member := oncall.ScheduleMember{ScheduleID: parts[1], UserID: in.UserID, Order: len(f.members)}
+		f.members = append(f.members, member)
+		reply(map[string]any{"member": member})
+	case len(parts) == 4 && parts[0] == "schedule" && parts[3] == "reorder":
+		reply(map[string]any{"members": f.members})
+	case r.URL.Path == "/integrations" && r.Method == http.MethodGet:
+		reply(map[string]any{"integrations": f.integrations})
+	case r.URL.Path == "/users" && r.Method == http.MethodGet:
+		reply(map[string]any{"users": f.users})
+	case r.URL.Path == "/contact-methods" && r.Method == http.MethodGet:
+		var methods []oncall.ContactMethod
+		for _, m := range f.contactMethods {
+			if m.UserID == r.URL.Query().Get("userId") {
+				methods = append(methods, m)
+			}
+		}
+		reply(map[string]any{"contactMethods": methods})
+	default:
+		http.Error(w, `{"error":"unexpected request"}`, http.StatusTeapot)
+	}
+}
+
+const testDocument = `
+version: 1
+relays:
+  - key: eng
+    name: Engineering
+    rules:
+      - key: page
+        name: Page primary
+        type: schedule_notify
+        config:
+          scheduleId: primary
+          notificationMethod: sms
+      - key: check
+        name: Acknowledged?
+        type: conditional
+        config:
+          condition: acknowledged
+          trueRuleId: done
+      - key: escalate
+        name: Escalate
+        type: escalate
+        config:
+          maxAttempts: 3
+          escalateAfter: 60
+          resetToRuleId: page
+      - key: done
+        name: Done
+        type: webhook
+        config:
+          endpoint: https://example.com/done
+schedules:
+  - key: primary
+    name: Primary
+    relay: eng
+    type: weekly
+    startDay: monday
+    startTime: "09:00"
+    members: [alice, bob]
+`
+
+func newTestSyncer(t *testing.T, api *fakeAPI) *Syncer {
+	t.Helper()
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+	client, err := oncall.NewClient(oncall.Config{APIKey: "test-key", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return New(client)
+}
+
+func TestLoad(t *testing.T) {
+	doc, err := Load(strings.NewReader(testDocument))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(doc.Relays) != 1 || len(doc.Relays[0].Rules) != 4 || len(doc.Schedules) != 1 {
+		t.Fatalf("unexpected document: %+v", doc)
+	}
+
+	if _, err := Load(strings.NewReader(`{"version": 1, "schedules": [{"key": "s", "relay": "missing"}]}`)); err == nil {
+		t.Fatal("expected error for schedule pointing at unknown relay")
+	}
+	if _, err := Load(strings.NewReader(`version: 2`)); err == nil {
+		t.Fatal("expected error for unsupported version")
+	}
+}
+
+func TestSync(t *testing.T) {
+	doc, err := Load(strings.NewReader(testDocument))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	api := &fakeAPI{}
+	syncer := newTestSyncer(t, api)
+	ctx := context.Background()
+
+	plan, err := syncer.Sync(ctx, doc, Options{DryRun: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(api.calls) != 0 {
+		t.Fatalf("dry run made changes: %v", api.calls)
+	}
+	if !strings.Contains(plan.String(), "Plan: 7 to create, 0 to update, 0 to delete, 0 to reorder.") {
+		t.Fatalf("unexpected plan:\n%s", plan)
+	}
+
+	if _, err := syncer.Sync(ctx, doc, Options{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	schedule := api.schedules[0]
+	byKey := make(map[string]oncall.RelayRule)
+	for _, rule := range api.rules {
+		byKey[*rule.ExternalKey] = rule
+	}
+	if byKey["page"].Config["scheduleId"] != schedule.ID {
+		t.Fatalf("schedule key not resolved: %v", byKey["page"].Config)
+	}
+	if byKey["check"].Config["trueRuleId"] != byKey["done"].ID {
+		t.Fatalf("forward rule reference not resolved: %v", byKey["check"].Config)
+	}
+	if byKey["escalate"].Config["resetToRuleId"] != byKey["page"].ID {
+		t.Fatalf("backward rule reference not resolved: %v", byKey["escalate"].Config)
+	}
+
+	plan, err = syncer.Plan(ctx, doc, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !plan.Empty() {
+		t.Fatalf("expected no changes after apply, got:\n%s", plan)
+	}
+
+	doc.Relays[0].Name = "Engineering Primary"
+	doc.Relays[0].Rules = doc.Relays[0].Rules[:1]
+	doc.Relays[0].Rules[0].Config = map[string]any{"scheduleId": "primary", "notificationMethod": "voice"}
+	api.calls = nil
+	plan, err = syncer.Sync(ctx, doc, Options{Prune: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{
+		"~ update relay eng",
+		"~ update rule eng/page",
+		"- delete rule eng/check",
+		"- delete rule eng/escalate",
+		"- delete rule eng/done",
+	}
+	var got []string
+	for _, a := range plan.Actions {
+		got = append(got, a.String())
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("unexpected actions:\n%s", plan)
+	}
+	if len(api.rules) != 1 || api.rules[0].Config["notificationMethod"] != "voice" {
+		t.Fatalf("unexpected rules after prune: %+v", api.rules)
+	}
+}
@@ -7,11 +7,12 @@ import (
 )
 
 type ContactMethodResource struct {
-	http *httpClient
+	http     *httpClient
+	validate bool
 }
 
-func newContactMethodResource(http *httpClient) *ContactMethodResource {
-	return &ContactMethodResource{http: http}
+func newContactMethodResource(http *httpClient, validate bool) *ContactMethodResource {
+	return &ContactMethodResource{http: http, validate: validate}
 }
 
 func (c *ContactMethodResource) List(ctx context.Context, params ListContactMethodsParams) ([]ContactMethod, error) {
@@ -28,7 +29,16 @@ func (c *ContactMethodResource) List(ctx context.Context, params ListContactMeth
 	return result.ContactMethods, nil
 }
 
+// Create adds a contact method. Unless Config.SkipContactValueValidation is
+// set, the value is checked against the transport's format first (see
+// ValidateContactValue).
 func (c *ContactMethodResource) Create(ctx context.Context, input CreateContactMethodInput) (*ContactMethod, error) {
+	if c.validate {
+		if err := ValidateContactValue(input.Transport, input.Value); err != nil {
+			return nil, &ValidationError{OnCallError: OnCallError{Message: err.Error(), Err: err}}
+		}
+	}
+
 	var result struct {
 		ContactMethod ContactMethod `json:"contactMethod"`
 	}
@@ -38,6 +48,79 @@ func (c *ContactMethodResource) Create(ctx context.Context, input CreateContactM
 	return &result.ContactMethod, nil
 }
 
+// Update changes a contact method. Changing the value resets Verified to false
+// on the server, so the returned method must be verified again. A new value is
+// validated like in Create; unless input.Transport is set, that costs a List
+// call to look up the method's transport.
+func (c *ContactMethodResource) Update(ctx context.Context, id string, input UpdateContactMethodInput) (*ContactMethod, error) {
+	if c.validate && input.Value != nil {
+		transport := input.Transport
+		if transport == "" {
+			method, err := c.find(ctx, id, input.UserID)
+			if err != nil {
+				return nil, err
+			}
+			transport = ContactMethodTransport(method.Transport)
+		}
+		if err := ValidateContactValue(transport, *input.Value); err != nil {
+			return nil, &ValidationError{OnCallError: OnCallError{Message: err.Error(), Err: err}}
+		}
+	}
+
+	var result struct {
+		ContactMethod ContactMethod `json:"contactMethod"`
+	}
+	path := fmt.Sprintf("/contact-methods/%s", id)
+	if err := c.http.put(ctx, path, input, &result); err != nil {
+		return nil, err
+	}
+	return &result.ContactMethod, nil
+}
+
+func (c *ContactMethodResource) SendVerification(ctx context.Context, id string, input SendContactMethodVerificationInput) error {
+	if err := c.ensureUnverified(ctx, id, input.UserID); err != nil {
+		return err
+	}
+
+	var result struct {
+		Success bool `json:"success"`
+	}
+	path := fmt.Sprintf("/contact-methods/%s/verification", id)
+	if err := c.http.post(ctx, path, input, &result); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *ContactMethodResource) ConfirmVerification(ctx context.Context, id string, input ConfirmContactMethodVerificationInput) (*ContactMethod, error) {
+	if input.Code == "" {
+		return nil, &ValidationError{OnCallError: OnCallError{Message: "verification code is required"}}
+	}
+	if err := c.ensureUnverified(ctx, id, input.UserID); err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		ContactMethod ContactMethod `json:"contactMethod"`
+	}
+	path := fmt.Sprintf("/contact-methods/%s/verification/confirm", id)
+	if err := c.http.post(ctx, path, input, &result); err != nil {
+		return nil, err
+	}
+	return &result.ContactMethod, nil
+}
+
+func (c *ContactMethodResource) SendTest(ctx context.Context, id string, input SendContactMethodTestInput) error {
+	var result struct {
+		Success bool `json:"success"`
+	}
+	path := fmt.Sprintf("/contact-methods/%s/test", id)
+	if err := c.http.post(ctx, path, input, &result); err != nil {
+		return err
+	}
+	return nil
+}
+
 func (c *ContactMethodResource) Delete(ctx context.Context, id string, params DeleteContactMethodParams) error {
 	query := url.Values{}
 	query.Set("userId", params.UserID)
@@ -68,6 +151,40 @@ func (c *ContactMethodResource) CreateSafe(ctx context.Context, input CreateCont
 	return Result[ContactMethod]{Data: method}
 }
 
+func (c *ContactMethodResource) UpdateSafe(ctx context.Context, id string, input UpdateContactMethodInput) Result[ContactMethod] {
+	method, err := c.Update(ctx, id, input)
+	if err != nil {
+		return Result[ContactMethod]{Error: err}
+	}
+	return Result[ContactMethod]{Data: method}
+}
+
+func (c *ContactMethodResource) SendVerificationSafe(ctx context.Context, id string, input SendContactMethodVerificationInput) Result[bool] {
+	err := c.SendVerification(ctx, id, input)
+	if err != nil {
+		return Result[bool]{Error: err}
+	}
+	success := true
+	return Result[bool]{Data: &success}
+}
+
+func (c *ContactMethodResource) ConfirmVerificationSafe(ctx context.Context, id string, input ConfirmContactMethodVerificationInput) Result[ContactMethod] {
+	method, err := c.ConfirmVerification(ctx, id, input)
+	if err != nil {
+		return Result[ContactMethod]{Error: err}
+	}
+	return Result[ContactMethod]{Data: method}
+}
+
+func (c *ContactMethodResource) SendTestSafe(ctx context.Context, id string, input SendContactMethodTestInput) Result[bool] {
+	err := c.SendTest(ctx, id, input)
+	if err != nil {
+		return Result[bool]{Error: err}
+	}
+	success := true
+	return Result[bool]{Data: &success}
+}
+
 func (c *ContactMethodResource) DeleteSafe(ctx context.Context, id string, params DeleteContactMethodParams) Result[bool] {
 	err := c.Delete(ctx, id, params)
 	if err != nil {
@@ -76,3 +193,30 @@ func (c *ContactMethodResource) DeleteSafe(ctx context.Context, id string, param
 	success := true
 	return Result[bool]{Data: &success}
 }
+
+func (c *ContactMethodResource) ensureUnverified(ctx context.Context, id, userID string) error {
+	method, err := c.find(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	if method.Verified {
+		return &ValidationError{OnCallError: OnCallError{
+			Message: fmt.Sprintf("contact method %s is already verified", id),
+			Err:     ErrContactMethodAlreadyVerified,
+		}}
+	}
+	return nil
+}
+
+func (c *ContactMethodResource) find(ctx context.Context, id, userID string) (*ContactMethod, error) {
+	methods, err := c.List(ctx, ListContactMethodsParams{UserID: userID})
+	if err != nil {
+		return nil, err
+	}
+	for _, method := range methods {
+		if method.ID == id {
+			return &method, nil
+		}
+	}
+	return nil, &NotFoundError{OnCallError: OnCallError{Message: fmt.Sprintf("contact method %s not found", id)}}
+}
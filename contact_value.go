@@ -0,0 +1,138 @@
+package oncall
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	e164Pattern        = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+	slackMemberPattern = regexp.MustCompile(`^[UW][A-Z0-9]{8,}$`)
+)
+
+// ContactValue is a transport-specific contact method value. Use
+// NewContactMethodInput to turn one into a CreateContactMethodInput.
+type ContactValue interface {
+	Transport() ContactMethodTransport
+	Value() string
+	Validate() error
+}
+
+type EmailValue struct {
+	Address string
+}
+
+func (v EmailValue) Transport() ContactMethodTransport { return TransportEmail }
+func (v EmailValue) Value() string                     { return v.Address }
+
+func (v EmailValue) Validate() error {
+	addr, err := mail.ParseAddress(v.Address)
+	if err != nil || addr.Address != v.Address {
+		return fmt.Errorf("invalid email address %q", v.Address)
+	}
+	return nil
+}
+
+type SMSValue struct {
+	PhoneNumber string
+}
+
+func (v SMSValue) Transport() ContactMethodTransport { return TransportSMS }
+func (v SMSValue) Value() string                     { return v.PhoneNumber }
+func (v SMSValue) Validate() error                   { return validatePhoneNumber(v.PhoneNumber) }
+
+type VoiceValue struct {
+	PhoneNumber string
+}
+
+func (v VoiceValue) Transport() ContactMethodTransport { return TransportVoice }
+func (v VoiceValue) Value() string                     { return v.PhoneNumber }
+func (v VoiceValue) Validate() error                   { return validatePhoneNumber(v.PhoneNumber) }
+
+type PushValue struct {
+	DeviceToken string
+}
+
+func (v PushValue) Transport() ContactMethodTransport { return TransportPush }
+func (v PushValue) Value() string                     { return v.DeviceToken }
+
+func (v PushValue) Validate() error {
+	if v.DeviceToken == "" || strings.ContainsAny(v.DeviceToken, " \t\r\n") {
+		return fmt.Errorf("invalid push device token %q", v.DeviceToken)
+	}
+	return nil
+}
+
+type SlackValue struct {
+	MemberID string
+}
+
+func (v SlackValue) Transport() ContactMethodTransport { return TransportSlack }
+func (v SlackValue) Value() string                     { return v.MemberID }
+
+func (v SlackValue) Validate() error {
+	if !slackMemberPattern.MatchString(v.MemberID) {
+		return fmt.Errorf("invalid Slack member ID %q", v.MemberID)
+	}
+	return nil
+}
+
+type WebhookValue struct {
+	URL string
+}
+
+func (v WebhookValue) Transport() ContactMethodTransport { return TransportWebhook }
+func (v WebhookValue) Value() string                     { return v.URL }
+func (v WebhookValue) Validate() error                   { return validateAbsoluteURL(v.URL) }
+
+func NewContactMethodInput(userID string, value ContactValue) (CreateContactMethodInput, error) {
+	if err := value.Validate(); err != nil {
+		return CreateContactMethodInput{}, &ValidationError{OnCallError: OnCallError{Message: err.Error(), Err: err}}
+	}
+	return CreateContactMethodInput{
+		UserID:    userID,
+		Transport: value.Transport(),
+		Value:     value.Value(),
+	}, nil
+}
+
+// ValidateContactValue checks value against the format expected by transport.
+// Transports unknown to this SDK version are left for the server to validate.
+func ValidateContactValue(transport ContactMethodTransport, value string) error {
+	var v ContactValue
+	switch transport {
+	case TransportEmail:
+		v = EmailValue{Address: value}
+	case TransportSMS:
+		v = SMSValue{PhoneNumber: value}
+	case TransportVoice:
+		v = VoiceValue{PhoneNumber: value}
+	case TransportPush:
+		v = PushValue{DeviceToken: value}
+	case TransportSlack:
+		v = SlackValue{MemberID: value}
+	case TransportWebhook:
+		v = WebhookValue{URL: value}
+	default:
+		return nil
+	}
+	return v.Validate()
+}
+
+func validatePhoneNumber(number string) error {
+	if !e164Pattern.MatchString(number) {
+		return fmt.Errorf("invalid phone number %q: must be in E.164 format", number)
+	}
+	return nil
+}
+
+func validateAbsoluteURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || !u.IsAbs() || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return fmt.Errorf("invalid URL %q: must be an absolute http or https URL", raw)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package oncall
+
+import "testing"
+
+func TestValidateContactValue(t *testing.T) {
+	tests := []struct {
+		name      string
+		transport ContactMethodTransport
+		value     string
+		wantErr   bool
+	}{
+		{"valid email", TransportEmail, "oncall@example.com", false},
+		{"email with display name", TransportEmail, "On Call <oncall@example.com>", true},
+		{"valid sms", TransportSMS, "+14155550123", false},
+		{"sms without plus", TransportSMS, "4155550123", true},
+		{"valid voice", TransportVoice, "+442071838750", false},
+		{"voice with spaces", TransportVoice, "+44 20 7183 8750", true},
+		{"valid push", TransportPush, "f3b1c2d4e5", false},
+		{"empty push", TransportPush, "", true},
+		{"valid slack", TransportSlack, "U024BE7LH", false},
+		{"slack channel id", TransportSlack, "C024BE7LH", true},
+		{"valid webhook", TransportWebhook, "https://hooks.example.com/page", false},
+		{"relative webhook", TransportWebhook, "/page", true},
+		{"unknown transport", ContactMethodTransport("pager"), "anything", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateContactValue(tt.transport, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateContactValue(%s, %q) error = %v, wantErr %v", tt.transport, tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}
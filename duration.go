@@ -0,0 +1,173 @@
+package oncall
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Seconds is a duration sent to the API as a whole number of seconds.
+//
+// Seconds is an int so that configs keep their wire format, which also means
+// untyped constants still compile: WaitConfig{Duration: 300} is 300 seconds,
+// not 300 nanoseconds. Build values with SecondsOf instead of literals.
+type Seconds int
+
+// Millis is a duration sent to the API as a whole number of milliseconds.
+// Like Seconds it accepts untyped constants; build values with MillisOf.
+type Millis int
+
+// SecondsOf converts d to Seconds, truncating to whole seconds. It is the
+// way to build Seconds values.
+func SecondsOf(d time.Duration) Seconds {
+	return Seconds(d / time.Second)
+}
+
+// MillisOf converts d to Millis, truncating to whole milliseconds. It is the
+// way to build Millis values.
+func MillisOf(d time.Duration) Millis {
+	return Millis(d / time.Millisecond)
+}
+
+func (s Seconds) Duration() time.Duration {
+	return time.Duration(s) * time.Second
+}
+
+func (m Millis) Duration() time.Duration {
+	return time.Duration(m) * time.Millisecond
+}
+
+func (s Seconds) String() string { return s.Duration().String() }
+func (m Millis) String() string  { return m.Duration().String() }
+
+// UnmarshalJSON accepts a number of seconds or a Go duration string such as
+// "5m". The API only sends numbers; strings are accepted for hand-written
+// configs and are always marshaled back as numbers.
+func (s *Seconds) UnmarshalJSON(data []byte) error {
+	n, err := unmarshalDuration(data, time.Second)
+	*s = Seconds(n)
+	return err
+}
+
+// UnmarshalJSON accepts a number of milliseconds or a Go duration string
+// such as "1.5s", as Seconds.UnmarshalJSON does.
+func (m *Millis) UnmarshalJSON(data []byte) error {
+	n, err := unmarshalDuration(data, time.Millisecond)
+	*m = Millis(n)
+	return err
+}
+
+func unmarshalDuration(data []byte, unit time.Duration) (int, error) {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return 0, err
+	}
+	switch v := v.(type) {
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("duration %v is not a whole number of %s", v, unitName(unit))
+		}
+		return int(v), nil
+	case string:
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return 0, err
+		}
+		if d%unit != 0 {
+			return 0, fmt.Errorf("duration %q is not a whole number of %s", v, unitName(unit))
+		}
+		return int(d / unit), nil
+	case nil:
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("invalid duration %s", data)
+	}
+}
+
+func unitName(unit time.Duration) string {
+	if unit == time.Millisecond {
+		return "milliseconds"
+	}
+	return "seconds"
+}
+
+// TimeOfDay is a wall clock time, encoded as "HH:MM".
+type TimeOfDay struct {
+	Hour   int
+	Minute int
+}
+
+func NewTimeOfDay(hour, minute int) (TimeOfDay, error) {
+	t := TimeOfDay{Hour: hour, Minute: minute}
+	return t, t.Validate()
+}
+
+// ParseTimeOfDay parses "HH:MM". "HH:MM:SS" is accepted as long as the
+// seconds are zero.
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	for _, layout := range []string{"15:04", "15:04:05"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			if t.Second() != 0 {
+				return TimeOfDay{}, fmt.Errorf("time of day %q must not have seconds", s)
+			}
+			return TimeOfDay{Hour: t.Hour(), Minute: t.Minute()}, nil
+		}
+	}
+	return TimeOfDay{}, fmt.Errorf("time of day %q is not of the form HH:MM", s)
+}
+
+// MustParseTimeOfDay is like ParseTimeOfDay but panics on invalid input. It
+// is meant for constants such as MustParseTimeOfDay("09:00").
+func MustParseTimeOfDay(s string) TimeOfDay {
+	t, err := ParseTimeOfDay(s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func (t TimeOfDay) Validate() error {
+	if t.Hour < 0 || t.Hour > 23 || t.Minute < 0 || t.Minute > 59 {
+		return fmt.Errorf("time of day %02d:%02d is out of range", t.Hour, t.Minute)
+	}
+	return nil
+}
+
+// Minutes returns the number of minutes after midnight.
+func (t TimeOfDay) Minutes() int {
+	return t.Hour*60 + t.Minute
+}
+
+// On returns the time t on the date of day in loc.
+func (t TimeOfDay) On(day time.Time, loc *time.Location) time.Time {
+	y, m, d := day.In(loc).Date()
+	return time.Date(y, m, d, t.Hour, t.Minute, 0, 0, loc)
+}
+
+func (t TimeOfDay) String() string {
+	return fmt.Sprintf("%02d:%02d", t.Hour, t.Minute)
+}
+
+func (t TimeOfDay) MarshalText() ([]byte, error) {
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText parses "HH:MM". An empty string leaves midnight.
+func (t *TimeOfDay) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+	if s == "" {
+		*t = TimeOfDay{}
+		return nil
+	}
+	parsed, err := ParseTimeOfDay(s)
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
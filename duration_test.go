@@ -0,0 +1,74 @@
+package oncall
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDurationJSON(t *testing.T) {
+	var cfg WaitConfig
+	if err := json.Unmarshal([]byte(`{"duration": 300}`), &cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Duration.Duration() != 5*time.Minute {
+		t.Fatalf("unexpected duration: %v", cfg.Duration)
+	}
+	if err := json.Unmarshal([]byte(`{"duration": "2m"}`), &cfg); err != nil || cfg.Duration != 120 {
+		t.Fatalf("expected duration string to parse, got %v (%v)", cfg.Duration, err)
+	}
+	if b, err := json.Marshal(cfg); err != nil || string(b) != `{"duration":120}` {
+		t.Fatalf("expected duration string to marshal as seconds, got %s (%v)", b, err)
+	}
+	if err := json.Unmarshal([]byte(`{"duration": "1500ms"}`), &cfg); err == nil {
+		t.Fatal("expected error for fractional seconds")
+	}
+
+	var webhook WebhookConfig
+	if err := json.Unmarshal([]byte(`{"endpoint": "https://example.com", "timeout": "1.5s"}`), &webhook); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if webhook.Timeout == nil || *webhook.Timeout != 1500 {
+		t.Fatalf("expected duration string to parse as milliseconds, got %v", webhook.Timeout)
+	}
+
+	timeout := MillisOf(1500 * time.Millisecond)
+	b, err := json.Marshal(WebhookConfig{Endpoint: "https://example.com", Timeout: &timeout})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(b) != `{"endpoint":"https://example.com","timeout":1500}` {
+		t.Fatalf("unexpected encoding: %s", b)
+	}
+}
+
+func TestTimeOfDay(t *testing.T) {
+	for _, s := range []string{"09:30", "09:30:00"} {
+		tod, err := ParseTimeOfDay(s)
+		if err != nil || tod != (TimeOfDay{Hour: 9, Minute: 30}) {
+			t.Fatalf("%s: unexpected result %v (%v)", s, tod, err)
+		}
+	}
+	for _, s := range []string{"9am", "24:00", "09:30:15"} {
+		if _, err := ParseTimeOfDay(s); err == nil {
+			t.Errorf("%s: expected error", s)
+		}
+	}
+
+	var schedule Schedule
+	if err := json.Unmarshal([]byte(`{"startTime": "17:45"}`), &schedule); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if schedule.StartTime.Minutes() != 17*60+45 {
+		t.Fatalf("unexpected start time: %v", schedule.StartTime)
+	}
+	b, _ := json.Marshal(CreateScheduleInput{StartTime: TimeOfDay{Hour: 9}})
+	var decoded map[string]any
+	json.Unmarshal(b, &decoded)
+	if decoded["startTime"] != "09:00" {
+		t.Fatalf("unexpected encoding: %s", b)
+	}
+	if _, err := json.Marshal(CreateScheduleInput{StartTime: TimeOfDay{Hour: 30}}); err == nil {
+		t.Fatal("expected error for out of range time of day")
+	}
+}
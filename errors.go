@@ -1,6 +1,15 @@
 package oncall
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrContactMethodAlreadyVerified = errors.New("contact method is already verified")
+	ErrRuleOrderMismatch            = errors.New("relay rule order does not match the requested order")
+)
 
 type OnCallError struct {
 	Message   string
@@ -25,6 +34,48 @@ type AuthError struct {
 
 type ValidationError struct {
 	OnCallError
+	Fields []FieldError
+}
+
+type FieldError struct {
+	Path    string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+func newFieldValidationError(fields []FieldError) *ValidationError {
+	messages := make([]string, len(fields))
+	for i, f := range fields {
+		messages[i] = f.Error()
+	}
+	return &ValidationError{
+		OnCallError: OnCallError{Message: "validation failed: " + strings.Join(messages, "; ")},
+		Fields:      fields,
+	}
+}
+
+// rollbackErrors collects the rules a rollback failed to restore, so that the
+// rollback can carry on with the rest.
+type rollbackErrors struct {
+	ruleIDs []string
+	errs    []error
+}
+
+func (r *rollbackErrors) add(ruleID string, err error) {
+	r.ruleIDs = append(r.ruleIDs, ruleID)
+	r.errs = append(r.errs, fmt.Errorf("rule %s: %w", ruleID, err))
+}
+
+// err returns cause joined with the rollback failures, if any.
+func (r *rollbackErrors) err(cause error) error {
+	if len(r.errs) == 0 {
+		return cause
+	}
+	failed := fmt.Errorf("rollback failed, rules left behind: %s: %w", strings.Join(r.ruleIDs, ", "), errors.Join(r.errs...))
+	return errors.Join(cause, failed)
 }
 
 type NotFoundError struct {
@@ -33,7 +33,7 @@ func main() {
 		RelayID:   relay.ID,
 		Type:      oncall.ScheduleTypeWeekly,
 		StartDay:  oncall.Monday,
-		StartTime: "09:00",
+		StartTime: oncall.TimeOfDay{Hour: 9},
 	})
 	if err != nil {
 		log.Fatal(err)
@@ -0,0 +1,333 @@
+package oncall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Policy builds the ordered rules of an escalation policy. Steps can be
+// labelled so conditionals and escalations can refer to them before the rules
+// exist; the labels are resolved to rule IDs by RelayRulesResource.ApplyPolicy.
+//
+//	policy := oncall.NewPolicy().
+//		Notify(primaryID, oncall.NotificationSMS).Label("page").
+//		Wait(5 * time.Minute).
+//		Escalate(3)
+type Policy struct {
+	group string
+	steps []policyStep
+	err   error
+}
+
+type policyStep struct {
+	name   string
+	label  string
+	group  string
+	config RuleConfig
+
+	trueLabel  string
+	falseLabel string
+	resetLabel string
+}
+
+func NewPolicy() *Policy {
+	return &Policy{}
+}
+
+func (p *Policy) add(name string, config RuleConfig) *Policy {
+	p.steps = append(p.steps, policyStep{name: name, group: p.group, config: config})
+	return p
+}
+
+func (p *Policy) fail(format string, args ...any) *Policy {
+	if p.err == nil {
+		p.err = fmt.Errorf(format, args...)
+	}
+	return p
+}
+
+// Group puts the steps added after it into the named rule group.
+func (p *Policy) Group(name string) *Policy {
+	p.group = name
+	return p
+}
+
+// Label names the previous step so If and EscalateTo can refer to it.
+func (p *Policy) Label(label string) *Policy {
+	if len(p.steps) == 0 {
+		return p.fail("Label(%q) called before any step", label)
+	}
+	for _, s := range p.steps {
+		if s.label == label {
+			return p.fail("duplicate label %q", label)
+		}
+	}
+	p.steps[len(p.steps)-1].label = label
+	return p
+}
+
+// Named overrides the generated rule name of the previous step.
+func (p *Policy) Named(name string) *Policy {
+	if len(p.steps) == 0 {
+		return p.fail("Named(%q) called before any step", name)
+	}
+	p.steps[len(p.steps)-1].name = name
+	return p
+}
+
+func (p *Policy) Notify(scheduleID string, method NotificationMethod) *Policy {
+	return p.add(fmt.Sprintf("Notify %s via %s", scheduleID, method), ScheduleNotifyConfig{
+		ScheduleID:         scheduleID,
+		NotificationMethod: &method,
+	})
+}
+
+func (p *Policy) Wait(d time.Duration) *Policy {
+	if d < time.Second {
+		return p.fail("wait duration %s is shorter than one second", d)
+	}
+	return p.add(fmt.Sprintf("Wait %s", d), WaitConfig{Duration: SecondsOf(d)})
+}
+
+func (p *Policy) Webhook(config WebhookConfig) *Policy {
+	return p.add(fmt.Sprintf("Webhook %s", config.Endpoint), config)
+}
+
+func (p *Policy) Agent(config AgentConfig) *Policy {
+	return p.add(fmt.Sprintf("Agent %s", config.AgentType), config)
+}
+
+func (p *Policy) ExternalAPI(config ExternalApiConfig) *Policy {
+	return p.add(fmt.Sprintf("External API %s", config.APIType), config)
+}
+
+// If adds a conditional step. An empty then or otherwise label falls through
+// to the next step.
+func (p *Policy) If(condition string, value *string, then, otherwise string) *Policy {
+	return p.When(ConditionalConfig{Condition: condition, ConditionValue: value}, then, otherwise)
+}
+
+// When adds a conditional step built with one of the If* constructors, such
+// as IfSeverity(SeverityCritical).
+func (p *Policy) When(condition ConditionalConfig, then, otherwise string) *Policy {
+	name := fmt.Sprintf("If %s", condition.Condition)
+	if condition.ConditionValue != nil {
+		name = fmt.Sprintf("If %s %s", condition.Condition, *condition.ConditionValue)
+	}
+	p.add(name, ConditionalConfig{
+		Condition:      condition.Condition,
+		ConditionValue: condition.ConditionValue,
+	})
+	p.steps[len(p.steps)-1].trueLabel = then
+	p.steps[len(p.steps)-1].falseLabel = otherwise
+	return p
+}
+
+// Escalate restarts the policy from its first step, at most maxAttempts times.
+func (p *Policy) Escalate(maxAttempts int) *Policy {
+	return p.add(fmt.Sprintf("Escalate (max %d)", maxAttempts), EscalateConfig{MaxAttempts: maxAttempts})
+}
+
+// EscalateTo restarts the policy at the labelled step after waiting, at most
+// maxAttempts times.
+func (p *Policy) EscalateTo(label string, maxAttempts int, after time.Duration) *Policy {
+	p.add(fmt.Sprintf("Escalate to %s (max %d)", label, maxAttempts), EscalateConfig{
+		MaxAttempts:   maxAttempts,
+		EscalateAfter: SecondsOf(after),
+	})
+	p.steps[len(p.steps)-1].resetLabel = label
+	return p
+}
+
+// Inputs returns the policy's rules in order. Label references are not yet
+// resolved; ApplyPolicy fills them in once the rules have IDs.
+func (p *Policy) Inputs() ([]CreateRelayRuleInput, error) {
+	if err := p.check(); err != nil {
+		return nil, err
+	}
+
+	inputs := make([]CreateRelayRuleInput, len(p.steps))
+	for i, s := range p.steps {
+		order := i + 1
+		inputs[i] = CreateRelayRuleInput{
+			Name:        s.name,
+			RuleType:    s.config.RuleType(),
+			Order:       &order,
+			TypedConfig: s.config,
+		}
+		if s.group != "" {
+			group := s.group
+			inputs[i].Group = &group
+		}
+	}
+	return inputs, nil
+}
+
+func (p *Policy) check() error {
+	if p.err != nil {
+		return p.err
+	}
+	if len(p.steps) == 0 {
+		return fmt.Errorf("policy has no steps")
+	}
+	labels := make(map[string]bool)
+	for _, s := range p.steps {
+		if s.label != "" {
+			labels[s.label] = true
+		}
+	}
+	for _, s := range p.steps {
+		for _, ref := range []string{s.trueLabel, s.falseLabel, s.resetLabel} {
+			if ref != "" && !labels[ref] {
+				return fmt.Errorf("step %q refers to unknown label %q", s.name, ref)
+			}
+		}
+	}
+	return nil
+}
+
+// resolve returns the step's config with label references replaced by the
+// IDs in ids, and whether it had any references.
+func (s policyStep) resolve(ids map[string]string) (RuleConfig, bool) {
+	ref := func(label string) *string {
+		if label == "" {
+			return nil
+		}
+		id := ids[label]
+		return &id
+	}
+	switch cfg := s.config.(type) {
+	case ConditionalConfig:
+		if s.trueLabel == "" && s.falseLabel == "" {
+			return cfg, false
+		}
+		cfg.TrueRuleID = ref(s.trueLabel)
+		cfg.FalseRuleID = ref(s.falseLabel)
+		return cfg, true
+	case EscalateConfig:
+		if s.resetLabel == "" {
+			return cfg, false
+		}
+		cfg.ResetToRuleID = ref(s.resetLabel)
+		return cfg, true
+	}
+	return s.config, false
+}
+
+// ApplyPolicy replaces the rules of a relay with the policy's rules, so that
+// the old and new rules never page together:
+//
+//  1. The new rules are created disabled, ordered after the existing rules,
+//     and branch labels are resolved to their IDs. If this fails, the rules
+//     created so far are removed again.
+//  2. The relay's previous rules are deleted. If a delete fails, nothing is
+//     undone: the error lists the previous rules still present and the new
+//     rules left disabled.
+//  3. The new rules are enabled and given the policy's orders. Every rule is
+//     attempted; the error lists the rules left disabled.
+//
+// Rollback failures are reported in the error along with the rules left
+// behind.
+func (r *RelayRulesResource) ApplyPolicy(ctx context.Context, relayID string, policy *Policy) ([]RelayRule, error) {
+	inputs, err := policy.Inputs()
+	if err != nil {
+		return nil, &ValidationError{OnCallError: OnCallError{Message: err.Error(), Err: err}}
+	}
+
+	existing, err := r.List(ctx, relayID, nil)
+	if err != nil {
+		return nil, err
+	}
+	maxOrder := 0
+	for _, rule := range existing {
+		maxOrder = max(maxOrder, rule.Order)
+	}
+
+	created := make([]RelayRule, 0, len(inputs))
+	rollback := func(cause error) error {
+		var failed rollbackErrors
+		for _, rule := range created {
+			if err := r.Delete(ctx, relayID, rule.ID); err != nil {
+				failed.add(rule.ID, err)
+			}
+		}
+		return failed.err(cause)
+	}
+
+	disabled := false
+	ids := make(map[string]string)
+	for i, input := range inputs {
+		order := maxOrder + i + 1
+		input.Order = &order
+		input.Enabled = &disabled
+		rule, err := r.Create(ctx, relayID, input)
+		if err != nil {
+			return nil, rollback(err)
+		}
+		created = append(created, *rule)
+		if label := policy.steps[i].label; label != "" {
+			ids[label] = rule.ID
+		}
+	}
+
+	for i, step := range policy.steps {
+		cfg, ok := step.resolve(ids)
+		if !ok {
+			continue
+		}
+		rule, err := r.Update(ctx, relayID, created[i].ID, UpdateRelayRuleInput{TypedConfig: cfg})
+		if err != nil {
+			return nil, rollback(err)
+		}
+		created[i] = *rule
+	}
+
+	for i, rule := range existing {
+		if err := r.Delete(ctx, relayID, rule.ID); err != nil {
+			var remaining []string
+			for _, rule := range existing[i:] {
+				remaining = append(remaining, rule.ID)
+			}
+			return created, fmt.Errorf("delete previous rule %s: %w (previous rules %s remain; new rules %s are disabled)",
+				rule.ID, err, strings.Join(remaining, ", "), strings.Join(ruleIDs(created), ", "))
+		}
+	}
+
+	var failed []string
+	var errs []error
+	enabled := true
+	for i := range created {
+		input := UpdateRelayRuleInput{Order: inputs[i].Order, Enabled: &enabled}
+		rule, err := r.Update(ctx, relayID, created[i].ID, input)
+		if err != nil {
+			failed = append(failed, created[i].ID)
+			errs = append(errs, fmt.Errorf("rule %s: %w", created[i].ID, err))
+			continue
+		}
+		created[i] = *rule
+	}
+	if len(errs) > 0 {
+		return created, fmt.Errorf("enable new rules, rules %s left disabled: %w", strings.Join(failed, ", "), errors.Join(errs...))
+	}
+
+	return created, nil
+}
+
+func ruleIDs(rules []RelayRule) []string {
+	ids := make([]string, len(rules))
+	for i, rule := range rules {
+		ids[i] = rule.ID
+	}
+	return ids
+}
+
+func (r *RelayRulesResource) ApplyPolicySafe(ctx context.Context, relayID string, policy *Policy) Result[[]RelayRule] {
+	rules, err := r.ApplyPolicy(ctx, relayID, policy)
+	if err != nil {
+		return Result[[]RelayRule]{Error: err}
+	}
+	return Result[[]RelayRule]{Data: &rules}
+}
@@ -0,0 +1,165 @@
+package oncall
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPolicyInputs(t *testing.T) {
+	inputs, err := NewPolicy().
+		Notify("primary", NotificationSMS).
+		Wait(5 * time.Minute).
+		Webhook(WebhookConfig{Endpoint: "https://example.com/hook"}).
+		Escalate(3).
+		Inputs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantTypes := []RelayRuleType{RuleTypeScheduleNotify, RuleTypeWait, RuleTypeWebhook, RuleTypeEscalate}
+	if len(inputs) != len(wantTypes) {
+		t.Fatalf("expected %d inputs, got %d", len(wantTypes), len(inputs))
+	}
+	for i, input := range inputs {
+		if input.RuleType != wantTypes[i] || *input.Order != i+1 {
+			t.Fatalf("input %d: unexpected type %s or order %d", i, input.RuleType, *input.Order)
+		}
+	}
+	if wait := inputs[1].TypedConfig.(WaitConfig); wait.Duration != 300 {
+		t.Fatalf("unexpected wait duration: %d", wait.Duration)
+	}
+
+	if _, err := NewPolicy().Notify("primary", NotificationSMS).If("acknowledged", nil, "", "nowhere").Inputs(); err == nil {
+		t.Fatal("expected error for unknown label")
+	}
+}
+
+func TestApplyPolicy(t *testing.T) {
+	fake := &fakeRules{rules: []RelayRule{{ID: "old", Name: "old rule", RuleType: RuleTypeWait, Order: 1, Enabled: true}}}
+	fake.nextID = 10
+	handler := fake.handler(t)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete && strings.HasSuffix(r.URL.Path, "/old") {
+			fake.mu.Lock()
+			for _, rule := range fake.rules {
+				if rule.ID != "old" && (rule.Enabled || rule.Order <= 1) {
+					t.Errorf("new rule %s enabled or colliding with the old rule when it was deleted: %+v", rule.ID, rule)
+				}
+			}
+			fake.mu.Unlock()
+		}
+		handler(w, r)
+	})
+
+	policy := NewPolicy().
+		Notify("primary", NotificationSMS).Label("page").
+		Wait(5*time.Minute).
+		If("acknowledged", nil, "done", "").
+		Notify("secondary", NotificationVoice).
+		EscalateTo("page", 2, time.Minute).
+		Webhook(WebhookConfig{Endpoint: "https://example.com/resolved"}).Label("done")
+
+	rules, err := client.Relay.Rules.ApplyPolicy(context.Background(), "relay1", policy)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rules) != 6 || len(fake.rules) != 6 {
+		t.Fatalf("expected 6 rules, got %d returned and %d stored", len(rules), len(fake.rules))
+	}
+	if fake.find("old") >= 0 {
+		t.Fatal("expected previous rule to be deleted")
+	}
+	for i, rule := range rules {
+		if !rule.Enabled || rule.Order != i+1 {
+			t.Fatalf("expected rule %d to be enabled with order %d, got %+v", i, i+1, rule)
+		}
+	}
+
+	cond, err := RuleConfigAs[ConditionalConfig](rules[2])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cond.TrueRuleID == nil || *cond.TrueRuleID != rules[5].ID || cond.FalseRuleID != nil {
+		t.Fatalf("conditional not resolved: %+v", cond)
+	}
+	escalate, err := RuleConfigAs[EscalateConfig](rules[4])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if escalate.ResetToRuleID == nil || *escalate.ResetToRuleID != rules[0].ID {
+		t.Fatalf("escalation not resolved: %+v", escalate)
+	}
+	if analysis := AnalyzeRelay(rules); analysis.HasIssues() {
+		t.Fatalf("applied policy has issues: %+v", analysis.Issues)
+	}
+}
+
+func TestApplyPolicyRollback(t *testing.T) {
+	fake := &fakeRules{rules: []RelayRule{{ID: "old", Name: "old rule", RuleType: RuleTypeWait}}}
+	fake.nextID = 10
+	handler := fake.handler(t)
+	posts := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			posts++
+		}
+		if (r.Method == http.MethodPost && posts == 3) || (r.Method == http.MethodDelete && strings.HasSuffix(r.URL.Path, "/rule11")) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"rejected"}`))
+			return
+		}
+		handler(w, r)
+	})
+
+	policy := NewPolicy().
+		Notify("primary", NotificationSMS).
+		Notify("secondary", NotificationSMS).
+		Notify("tertiary", NotificationSMS)
+
+	var verr *ValidationError
+	_, err := client.Relay.Rules.ApplyPolicy(context.Background(), "relay1", policy)
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected the create error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "left behind: rule11") {
+		t.Fatalf("expected error to name rule11, got %v", err)
+	}
+	if fake.find("rule12") >= 0 {
+		t.Fatal("expected rollback to continue past the failed delete")
+	}
+	if fake.find("old") < 0 || fake.find("rule11") < 0 {
+		t.Fatalf("unexpected rules after rollback: %+v", fake.rules)
+	}
+}
+
+func TestApplyPolicyDeleteFailure(t *testing.T) {
+	fake := &fakeRules{rules: []RelayRule{
+		{ID: "old1", RuleType: RuleTypeWait, Order: 1, Enabled: true},
+		{ID: "old2", RuleType: RuleTypeWait, Order: 2, Enabled: true},
+	}}
+	fake.nextID = 10
+	handler := fake.handler(t)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete && strings.HasSuffix(r.URL.Path, "/old2") {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"rejected"}`))
+			return
+		}
+		handler(w, r)
+	})
+
+	policy := NewPolicy().Notify("primary", NotificationSMS).Notify("secondary", NotificationSMS)
+	_, err := client.Relay.Rules.ApplyPolicy(context.Background(), "relay1", policy)
+	if err == nil || !strings.Contains(err.Error(), "previous rules old2 remain; new rules rule11, rule12 are disabled") {
+		t.Fatalf("expected error listing what is left, got %v", err)
+	}
+	for _, id := range []string{"rule11", "rule12"} {
+		if i := fake.find(id); i < 0 || fake.rules[i].Enabled {
+			t.Fatalf("expected %s to exist and stay disabled: %+v", id, fake.rules)
+		}
+	}
+}
@@ -1,16 +1,19 @@
 package oncall
 
-import "context"
+import (
+	"context"
+	"fmt"
+)
 
 type RelayResource struct {
 	http  *httpClient
 	Rules *RelayRulesResource
 }
 
-func newRelayResource(http *httpClient) *RelayResource {
+func newRelayResource(http *httpClient, validateRules bool) *RelayResource {
 	return &RelayResource{
 		http:  http,
-		Rules: newRelayRulesResource(http),
+		Rules: newRelayRulesResource(http, validateRules),
 	}
 }
 
@@ -34,6 +37,87 @@ func (r *RelayResource) List(ctx context.Context) ([]Relay, error) {
 	return result.Relays, nil
 }
 
+func (r *RelayResource) Get(ctx context.Context, relayID string) (*Relay, error) {
+	var result struct {
+		Relay Relay `json:"relay"`
+	}
+	path := fmt.Sprintf("/relay/%s", relayID)
+	if err := r.http.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+	return &result.Relay, nil
+}
+
+func (r *RelayResource) Update(ctx context.Context, relayID string, input UpdateRelayInput) (*Relay, error) {
+	var result struct {
+		Relay Relay `json:"relay"`
+	}
+	path := fmt.Sprintf("/relay/%s", relayID)
+	if err := r.http.put(ctx, path, input, &result); err != nil {
+		return nil, err
+	}
+	return &result.Relay, nil
+}
+
+func (r *RelayResource) Delete(ctx context.Context, relayID string) error {
+	var result struct {
+		Success bool `json:"success"`
+	}
+	path := fmt.Sprintf("/relay/%s", relayID)
+	if err := r.http.delete(ctx, path, &result); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpsertByExternalKey creates the relay identified by input.ExternalKey, or
+// updates its name and description if it already exists.
+func (r *RelayResource) UpsertByExternalKey(ctx context.Context, input CreateRelayInput) (*UpsertResult[Relay], error) {
+	if err := requireExternalKey(input.ExternalKey); err != nil {
+		return nil, err
+	}
+
+	relays, err := r.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var existing *Relay
+	for i := range relays {
+		if relays[i].ExternalKey != nil && *relays[i].ExternalKey == *input.ExternalKey {
+			existing = &relays[i]
+			break
+		}
+	}
+
+	if existing == nil {
+		relay, err := r.Create(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		return &UpsertResult[Relay]{Data: *relay, Action: UpsertCreated}, nil
+	}
+
+	var update UpdateRelayInput
+	changed := false
+	if existing.Name != input.Name {
+		update.Name = &input.Name
+		changed = true
+	}
+	if input.Description != nil && existing.Description != *input.Description {
+		update.Description = input.Description
+		changed = true
+	}
+	if !changed {
+		return &UpsertResult[Relay]{Data: *existing, Action: UpsertUnchanged}, nil
+	}
+
+	relay, err := r.Update(ctx, existing.ID, update)
+	if err != nil {
+		return nil, err
+	}
+	return &UpsertResult[Relay]{Data: *relay, Action: UpsertUpdated}, nil
+}
+
 func (r *RelayResource) CreateSafe(ctx context.Context, input CreateRelayInput) Result[Relay] {
 	relay, err := r.Create(ctx, input)
 	if err != nil {
@@ -49,3 +133,36 @@ func (r *RelayResource) ListSafe(ctx context.Context) Result[[]Relay] {
 	}
 	return Result[[]Relay]{Data: &relays}
 }
+
+func (r *RelayResource) GetSafe(ctx context.Context, relayID string) Result[Relay] {
+	relay, err := r.Get(ctx, relayID)
+	if err != nil {
+		return Result[Relay]{Error: err}
+	}
+	return Result[Relay]{Data: relay}
+}
+
+func (r *RelayResource) UpdateSafe(ctx context.Context, relayID string, input UpdateRelayInput) Result[Relay] {
+	relay, err := r.Update(ctx, relayID, input)
+	if err != nil {
+		return Result[Relay]{Error: err}
+	}
+	return Result[Relay]{Data: relay}
+}
+
+func (r *RelayResource) DeleteSafe(ctx context.Context, relayID string) Result[bool] {
+	err := r.Delete(ctx, relayID)
+	if err != nil {
+		return Result[bool]{Error: err}
+	}
+	success := true
+	return Result[bool]{Data: &success}
+}
+
+func (r *RelayResource) UpsertByExternalKeySafe(ctx context.Context, input CreateRelayInput) Result[UpsertResult[Relay]] {
+	result, err := r.UpsertByExternalKey(ctx, input)
+	if err != nil {
+		return Result[UpsertResult[Relay]]{Error: err}
+	}
+	return Result[UpsertResult[Relay]]{Data: result}
+}
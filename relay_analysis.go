@@ -0,0 +1,338 @@
+package oncall
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type RelayIssueKind string
+
+const (
+	IssueInvalidConfig     RelayIssueKind = "invalid_config"
+	IssueDanglingReference RelayIssueKind = "dangling_reference"
+	IssueDisabledReference RelayIssueKind = "disabled_reference"
+	IssueUnreachable       RelayIssueKind = "unreachable"
+	IssueAfterTerminal     RelayIssueKind = "after_terminal"
+	IssueUnboundedCycle    RelayIssueKind = "unbounded_cycle"
+)
+
+type RelayIssue struct {
+	Kind    RelayIssueKind
+	RuleID  string
+	Field   string
+	RuleIDs []string
+	Message string
+}
+
+type RelayAnalysis struct {
+	// Sequence holds the IDs of the enabled rules in execution order.
+	Sequence []string
+	Issues   []RelayIssue
+}
+
+func (a *RelayAnalysis) HasIssues() bool {
+	return len(a.Issues) > 0
+}
+
+// AnalyzeRelay checks the rules of a single relay, as returned by
+// Relay.Rules.List, without contacting the API.
+//
+// Enabled rules run in Order within their Group, and groups run in the order
+// of their lowest rule Order. Each rule falls through to the next one except
+// conditionals, which jump to TrueRuleID or FalseRuleID when set, and
+// escalations, which restart at ResetToRuleID (or the first rule) until
+// MaxAttempts is reached and then end the relay.
+func AnalyzeRelay(rules []RelayRule) *RelayAnalysis {
+	g, issues := newRelayGraph(rules)
+	analysis := &RelayAnalysis{Issues: issues}
+	for _, rule := range g.sequence {
+		analysis.Sequence = append(analysis.Sequence, rule.ID)
+	}
+
+	for _, rule := range g.sequence {
+		for _, ref := range g.references(rule.ID) {
+			target, ok := g.all[ref.to]
+			switch {
+			case !ok:
+				analysis.Issues = append(analysis.Issues, RelayIssue{
+					Kind:    IssueDanglingReference,
+					RuleID:  rule.ID,
+					Field:   ref.field,
+					Message: fmt.Sprintf("rule %q references missing rule %s in %s", rule.Name, ref.to, ref.field),
+				})
+			case !target.Enabled:
+				analysis.Issues = append(analysis.Issues, RelayIssue{
+					Kind:    IssueDisabledReference,
+					RuleID:  rule.ID,
+					Field:   ref.field,
+					Message: fmt.Sprintf("rule %q references disabled rule %q in %s", rule.Name, target.Name, ref.field),
+				})
+			}
+		}
+	}
+
+	reachable := g.reachable()
+	afterTerminal := false
+	for i, rule := range g.sequence {
+		if reachable[rule.ID] {
+			afterTerminal = false
+			continue
+		}
+		if i > 0 && (afterTerminal || g.terminal(g.sequence[i-1].ID)) {
+			afterTerminal = true
+			analysis.Issues = append(analysis.Issues, RelayIssue{
+				Kind:    IssueAfterTerminal,
+				RuleID:  rule.ID,
+				Message: fmt.Sprintf("rule %q follows a terminal step and is never run", rule.Name),
+			})
+			continue
+		}
+		analysis.Issues = append(analysis.Issues, RelayIssue{
+			Kind:    IssueUnreachable,
+			RuleID:  rule.ID,
+			Message: fmt.Sprintf("rule %q is not reachable from the first rule", rule.Name),
+		})
+	}
+
+	for _, cycle := range g.unboundedCycles() {
+		names := make([]string, len(cycle))
+		for i, id := range cycle {
+			names[i] = fmt.Sprintf("%q", g.all[id].Name)
+		}
+		analysis.Issues = append(analysis.Issues, RelayIssue{
+			Kind:    IssueUnboundedCycle,
+			RuleID:  cycle[0],
+			RuleIDs: cycle,
+			Message: fmt.Sprintf("rules %s form a loop with no escalation limit", strings.Join(names, ", ")),
+		})
+	}
+
+	return analysis
+}
+
+type relayEdge struct {
+	to      string
+	field   string
+	bounded bool
+}
+
+type relayGraph struct {
+	all      map[string]RelayRule
+	sequence []RelayRule
+	index    map[string]int
+	configs  map[string]RuleConfig
+}
+
+func newRelayGraph(rules []RelayRule) (*relayGraph, []RelayIssue) {
+	g := &relayGraph{
+		all:     make(map[string]RelayRule),
+		index:   make(map[string]int),
+		configs: make(map[string]RuleConfig),
+	}
+	var issues []RelayIssue
+
+	groupStart := make(map[string]int)
+	for _, rule := range rules {
+		if rule.DeletedAt != nil {
+			continue
+		}
+		g.all[rule.ID] = rule
+		if !rule.Enabled {
+			continue
+		}
+		g.sequence = append(g.sequence, rule)
+		if start, ok := groupStart[rule.Group]; !ok || rule.Order < start {
+			groupStart[rule.Group] = rule.Order
+		}
+
+		cfg, err := rule.TypedConfig()
+		if err != nil {
+			issues = append(issues, RelayIssue{
+				Kind:    IssueInvalidConfig,
+				RuleID:  rule.ID,
+				Field:   "config",
+				Message: err.Error(),
+			})
+			continue
+		}
+		g.configs[rule.ID] = cfg
+	}
+
+	slices.SortStableFunc(g.sequence, func(a, b RelayRule) int {
+		if a.Group != b.Group {
+			if c := groupStart[a.Group] - groupStart[b.Group]; c != 0 {
+				return c
+			}
+			return strings.Compare(a.Group, b.Group)
+		}
+		if c := a.Order - b.Order; c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	for i, rule := range g.sequence {
+		g.index[rule.ID] = i
+	}
+
+	return g, issues
+}
+
+func (g *relayGraph) first() string {
+	if len(g.sequence) == 0 {
+		return ""
+	}
+	return g.sequence[0].ID
+}
+
+// next returns the rule that follows ruleID by position, or "" at the end.
+func (g *relayGraph) next(ruleID string) string {
+	i, ok := g.index[ruleID]
+	if !ok || i+1 >= len(g.sequence) {
+		return ""
+	}
+	return g.sequence[i+1].ID
+}
+
+func (g *relayGraph) terminal(ruleID string) bool {
+	_, ok := g.configs[ruleID].(EscalateConfig)
+	return ok
+}
+
+// references lists the explicit rule IDs named in a rule's config.
+func (g *relayGraph) references(ruleID string) []relayEdge {
+	var refs []relayEdge
+	switch cfg := g.configs[ruleID].(type) {
+	case ConditionalConfig:
+		if cfg.TrueRuleID != nil {
+			refs = append(refs, relayEdge{to: *cfg.TrueRuleID, field: "config.trueRuleId"})
+		}
+		if cfg.FalseRuleID != nil {
+			refs = append(refs, relayEdge{to: *cfg.FalseRuleID, field: "config.falseRuleId"})
+		}
+	case EscalateConfig:
+		if cfg.ResetToRuleID != nil {
+			refs = append(refs, relayEdge{to: *cfg.ResetToRuleID, field: "config.resetToRuleId", bounded: cfg.MaxAttempts > 0})
+		}
+	}
+	return refs
+}
+
+// successors lists the rules that can run directly after ruleID. Edges to
+// missing or disabled rules are left out.
+func (g *relayGraph) successors(ruleID string) []relayEdge {
+	var edges []relayEdge
+	switch cfg := g.configs[ruleID].(type) {
+	case ConditionalConfig:
+		edges = append(edges, g.branch(cfg.TrueRuleID, ruleID, "config.trueRuleId"))
+		edges = append(edges, g.branch(cfg.FalseRuleID, ruleID, "config.falseRuleId"))
+	case EscalateConfig:
+		target := g.first()
+		if cfg.ResetToRuleID != nil {
+			target = *cfg.ResetToRuleID
+		}
+		edges = append(edges, relayEdge{to: target, field: "config.resetToRuleId", bounded: cfg.MaxAttempts > 0})
+	default:
+		edges = append(edges, relayEdge{to: g.next(ruleID)})
+	}
+
+	valid := edges[:0]
+	for _, e := range edges {
+		if _, ok := g.index[e.to]; ok {
+			valid = append(valid, e)
+		}
+	}
+	return valid
+}
+
+func (g *relayGraph) branch(target *string, ruleID, field string) relayEdge {
+	if target == nil {
+		return relayEdge{to: g.next(ruleID), field: field}
+	}
+	return relayEdge{to: *target, field: field}
+}
+
+func (g *relayGraph) reachable() map[string]bool {
+	seen := make(map[string]bool)
+	if len(g.sequence) == 0 {
+		return seen
+	}
+	queue := []string{g.first()}
+	seen[g.first()] = true
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, e := range g.successors(id) {
+			if !seen[e.to] {
+				seen[e.to] = true
+				queue = append(queue, e.to)
+			}
+		}
+	}
+	return seen
+}
+
+// unboundedCycles returns the strongly connected components formed by edges
+// that are not limited by an escalation's MaxAttempts, each in execution order.
+func (g *relayGraph) unboundedCycles() [][]string {
+	var (
+		index   = make(map[string]int)
+		lowlink = make(map[string]int)
+		onStack = make(map[string]bool)
+		stack   []string
+		counter int
+		cycles  [][]string
+	)
+
+	var visit func(id string)
+	visit = func(id string) {
+		index[id] = counter
+		lowlink[id] = counter
+		counter++
+		stack = append(stack, id)
+		onStack[id] = true
+
+		selfLoop := false
+		for _, e := range g.successors(id) {
+			if e.bounded {
+				continue
+			}
+			if e.to == id {
+				selfLoop = true
+			}
+			if _, seen := index[e.to]; !seen {
+				visit(e.to)
+				lowlink[id] = min(lowlink[id], lowlink[e.to])
+			} else if onStack[e.to] {
+				lowlink[id] = min(lowlink[id], index[e.to])
+			}
+		}
+
+		if lowlink[id] != index[id] {
+			return
+		}
+		var component []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == id {
+				break
+			}
+		}
+		if len(component) > 1 || selfLoop {
+			slices.SortFunc(component, func(a, b string) int { return g.index[a] - g.index[b] })
+			cycles = append(cycles, component)
+		}
+	}
+
+	for _, rule := range g.sequence {
+		if _, seen := index[rule.ID]; !seen {
+			visit(rule.ID)
+		}
+	}
+
+	slices.SortFunc(cycles, func(a, b []string) int { return g.index[a[0]] - g.index[b[0]] })
+	return cycles
+}
@@ -0,0 +1,123 @@
+package oncall
+
+import (
+	"slices"
+	"testing"
+)
+
+func testRule(id string, order int, ruleType RelayRuleType, config map[string]any) RelayRule {
+	return RelayRule{
+		ID:       id,
+		Name:     id,
+		Group:    "default",
+		Order:    order,
+		RuleType: ruleType,
+		Config:   config,
+		Enabled:  true,
+	}
+}
+
+func issueKinds(a *RelayAnalysis) map[RelayIssueKind][]string {
+	kinds := make(map[RelayIssueKind][]string)
+	for _, issue := range a.Issues {
+		kinds[issue.Kind] = append(kinds[issue.Kind], issue.RuleID)
+	}
+	return kinds
+}
+
+func TestAnalyzeRelay(t *testing.T) {
+	t.Run("clean relay", func(t *testing.T) {
+		analysis := AnalyzeRelay([]RelayRule{
+			testRule("escalate", 3, RuleTypeEscalate, map[string]any{"maxAttempts": 3, "escalateAfter": 300}),
+			testRule("notify", 1, RuleTypeScheduleNotify, map[string]any{"scheduleId": "s1"}),
+			testRule("wait", 2, RuleTypeWait, map[string]any{"duration": 300}),
+		})
+		if analysis.HasIssues() {
+			t.Fatalf("unexpected issues: %+v", analysis.Issues)
+		}
+		if !slices.Equal(analysis.Sequence, []string{"notify", "wait", "escalate"}) {
+			t.Fatalf("unexpected sequence: %v", analysis.Sequence)
+		}
+	})
+
+	t.Run("groups run in order of their first rule", func(t *testing.T) {
+		a := testRule("a", 5, RuleTypeWait, map[string]any{"duration": 60})
+		a.Group = "later"
+		b := testRule("b", 1, RuleTypeWait, map[string]any{"duration": 60})
+		c := testRule("c", 9, RuleTypeWait, map[string]any{"duration": 60})
+		analysis := AnalyzeRelay([]RelayRule{a, b, c})
+		if !slices.Equal(analysis.Sequence, []string{"b", "c", "a"}) {
+			t.Fatalf("unexpected sequence: %v", analysis.Sequence)
+		}
+	})
+
+	t.Run("dangling and disabled references", func(t *testing.T) {
+		disabled := testRule("off", 3, RuleTypeWait, map[string]any{"duration": 60})
+		disabled.Enabled = false
+		analysis := AnalyzeRelay([]RelayRule{
+			testRule("cond", 1, RuleTypeConditional, map[string]any{
+				"condition":   "severity",
+				"trueRuleId":  "ghost",
+				"falseRuleId": "off",
+			}),
+			testRule("wait", 2, RuleTypeWait, map[string]any{"duration": 60}),
+			disabled,
+		})
+		kinds := issueKinds(analysis)
+		if !slices.Equal(kinds[IssueDanglingReference], []string{"cond"}) {
+			t.Fatalf("expected dangling reference from cond, got %+v", analysis.Issues)
+		}
+		if !slices.Equal(kinds[IssueDisabledReference], []string{"cond"}) {
+			t.Fatalf("expected disabled reference from cond, got %+v", analysis.Issues)
+		}
+		if !slices.Equal(kinds[IssueUnreachable], []string{"wait"}) {
+			t.Fatalf("expected wait to be unreachable, got %+v", analysis.Issues)
+		}
+	})
+
+	t.Run("rules after escalation", func(t *testing.T) {
+		analysis := AnalyzeRelay([]RelayRule{
+			testRule("notify", 1, RuleTypeScheduleNotify, map[string]any{"scheduleId": "s1"}),
+			testRule("escalate", 2, RuleTypeEscalate, map[string]any{"maxAttempts": 2, "escalateAfter": 60}),
+			testRule("tail1", 3, RuleTypeWait, map[string]any{"duration": 60}),
+			testRule("tail2", 4, RuleTypeWait, map[string]any{"duration": 60}),
+		})
+		kinds := issueKinds(analysis)
+		if !slices.Equal(kinds[IssueAfterTerminal], []string{"tail1", "tail2"}) {
+			t.Fatalf("expected tail rules after terminal step, got %+v", analysis.Issues)
+		}
+		if len(kinds[IssueUnboundedCycle]) != 0 {
+			t.Fatalf("bounded escalation reported as cycle: %+v", analysis.Issues)
+		}
+	})
+
+	t.Run("unbounded cycles", func(t *testing.T) {
+		analysis := AnalyzeRelay([]RelayRule{
+			testRule("notify", 1, RuleTypeScheduleNotify, map[string]any{"scheduleId": "s1"}),
+			testRule("wait", 2, RuleTypeWait, map[string]any{"duration": 60}),
+			testRule("cond", 3, RuleTypeConditional, map[string]any{"condition": "acknowledged", "falseRuleId": "notify"}),
+			testRule("escalate", 4, RuleTypeEscalate, map[string]any{"maxAttempts": 0, "escalateAfter": 60, "resetToRuleId": "escalate"}),
+		})
+		var cycles [][]string
+		for _, issue := range analysis.Issues {
+			if issue.Kind == IssueUnboundedCycle {
+				cycles = append(cycles, issue.RuleIDs)
+			}
+		}
+		if len(cycles) != 2 {
+			t.Fatalf("expected 2 cycles, got %+v", analysis.Issues)
+		}
+		if !slices.Equal(cycles[0], []string{"notify", "wait", "cond"}) || !slices.Equal(cycles[1], []string{"escalate"}) {
+			t.Fatalf("unexpected cycles: %v", cycles)
+		}
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		analysis := AnalyzeRelay([]RelayRule{
+			testRule("wait", 1, RuleTypeWait, map[string]any{"duration": "five minutes"}),
+		})
+		if !slices.Equal(issueKinds(analysis)[IssueInvalidConfig], []string{"wait"}) {
+			t.Fatalf("expected invalid config issue, got %+v", analysis.Issues)
+		}
+	})
+}
@@ -7,11 +7,12 @@ import (
 )
 
 type RelayRulesResource struct {
-	http *httpClient
+	http     *httpClient
+	validate bool
 }
 
-func newRelayRulesResource(http *httpClient) *RelayRulesResource {
-	return &RelayRulesResource{http: http}
+func newRelayRulesResource(http *httpClient, validate bool) *RelayRulesResource {
+	return &RelayRulesResource{http: http, validate: validate}
 }
 
 func (r *RelayRulesResource) List(ctx context.Context, relayID string, params *ListRelayRulesParams) ([]RelayRule, error) {
@@ -25,6 +26,9 @@ func (r *RelayRulesResource) List(ctx context.Context, relayID string, params *L
 		if params.RuleType != nil {
 			query.Set("ruleType", string(*params.RuleType))
 		}
+		if params.Group != nil {
+			query.Set("group", *params.Group)
+		}
 		if len(query) > 0 {
 			path = fmt.Sprintf("%s?%s", path, query.Encode())
 		}
@@ -40,6 +44,15 @@ func (r *RelayRulesResource) List(ctx context.Context, relayID string, params *L
 }
 
 func (r *RelayRulesResource) Create(ctx context.Context, relayID string, input CreateRelayRuleInput) (*RelayRule, error) {
+	if r.validate {
+		if err := input.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if err := input.applyTypedConfig(); err != nil {
+		return nil, err
+	}
+
 	var result struct {
 		Rule RelayRule `json:"rule"`
 	}
@@ -62,6 +75,15 @@ func (r *RelayRulesResource) Get(ctx context.Context, relayID, ruleID string) (*
 }
 
 func (r *RelayRulesResource) Update(ctx context.Context, relayID, ruleID string, input UpdateRelayRuleInput) (*RelayRule, error) {
+	if r.validate {
+		if err := input.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if err := input.applyTypedConfig(); err != nil {
+		return nil, err
+	}
+
 	var result struct {
 		Rule RelayRule `json:"rule"`
 	}
@@ -94,6 +116,77 @@ func (r *RelayRulesResource) Reorder(ctx context.Context, relayID string, input
 	return result.Rules, nil
 }
 
+// UpsertByExternalKey creates the rule identified by input.ExternalKey in the
+// relay, or updates the fields that differ if it already exists. RuleType,
+// Config, Group, Order and Enabled are only compared when set, so a nil Config
+// leaves the rule's config unchanged.
+func (r *RelayRulesResource) UpsertByExternalKey(ctx context.Context, relayID string, input CreateRelayRuleInput) (*UpsertResult[RelayRule], error) {
+	if err := requireExternalKey(input.ExternalKey); err != nil {
+		return nil, err
+	}
+
+	rules, err := r.List(ctx, relayID, nil)
+	if err != nil {
+		return nil, err
+	}
+	var existing *RelayRule
+	for i := range rules {
+		if rules[i].ExternalKey != nil && *rules[i].ExternalKey == *input.ExternalKey {
+			existing = &rules[i]
+			break
+		}
+	}
+
+	if existing == nil {
+		rule, err := r.Create(ctx, relayID, input)
+		if err != nil {
+			return nil, err
+		}
+		return &UpsertResult[RelayRule]{Data: *rule, Action: UpsertCreated}, nil
+	}
+
+	desired := input
+	if err := desired.applyTypedConfig(); err != nil {
+		return nil, err
+	}
+
+	var update UpdateRelayRuleInput
+	changed := false
+	if existing.Name != desired.Name {
+		update.Name = &desired.Name
+		changed = true
+	}
+	if desired.RuleType != "" && existing.RuleType != desired.RuleType {
+		update.RuleType = &desired.RuleType
+		changed = true
+	}
+	if desired.Config != nil && !RuleConfigEqual(existing.Config, desired.Config) {
+		update.Config = desired.Config
+		changed = true
+	}
+	if desired.Group != nil && existing.Group != *desired.Group {
+		update.Group = desired.Group
+		changed = true
+	}
+	if desired.Order != nil && existing.Order != *desired.Order {
+		update.Order = desired.Order
+		changed = true
+	}
+	if desired.Enabled != nil && existing.Enabled != *desired.Enabled {
+		update.Enabled = desired.Enabled
+		changed = true
+	}
+	if !changed {
+		return &UpsertResult[RelayRule]{Data: *existing, Action: UpsertUnchanged}, nil
+	}
+
+	rule, err := r.Update(ctx, relayID, existing.ID, update)
+	if err != nil {
+		return nil, err
+	}
+	return &UpsertResult[RelayRule]{Data: *rule, Action: UpsertUpdated}, nil
+}
+
 func (r *RelayRulesResource) ListSafe(ctx context.Context, relayID string, params *ListRelayRulesParams) Result[[]RelayRule] {
 	rules, err := r.List(ctx, relayID, params)
 	if err != nil {
@@ -142,3 +235,11 @@ func (r *RelayRulesResource) ReorderSafe(ctx context.Context, relayID string, in
 	}
 	return Result[[]RelayRule]{Data: &rules}
 }
+
+func (r *RelayRulesResource) UpsertByExternalKeySafe(ctx context.Context, relayID string, input CreateRelayRuleInput) Result[UpsertResult[RelayRule]] {
+	result, err := r.UpsertByExternalKey(ctx, relayID, input)
+	if err != nil {
+		return Result[UpsertResult[RelayRule]]{Error: err}
+	}
+	return Result[UpsertResult[RelayRule]]{Data: result}
+}
@@ -0,0 +1,179 @@
+package oncall
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ListGroups returns the relay's rules grouped by Group. Groups are ordered
+// by their lowest rule Order and rules within a group by Order.
+func (r *RelayRulesResource) ListGroups(ctx context.Context, relayID string) ([]RuleGroup, error) {
+	rules, err := r.listOrdered(ctx, relayID)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []RuleGroup
+	index := make(map[string]int)
+	for _, rule := range rules {
+		i, ok := index[rule.Group]
+		if !ok {
+			i = len(groups)
+			index[rule.Group] = i
+			groups = append(groups, RuleGroup{Name: rule.Group})
+		}
+		groups[i].Rules = append(groups[i].Rules, rule)
+	}
+	return groups, nil
+}
+
+// RenameGroup moves every rule of a group into the group named to.
+func (r *RelayRulesResource) RenameGroup(ctx context.Context, relayID, from, to string) ([]RelayRule, error) {
+	return r.updateGroup(ctx, relayID, from,
+		func(RelayRule) UpdateRelayRuleInput { return UpdateRelayRuleInput{Group: &to} },
+		func(rule RelayRule) UpdateRelayRuleInput { return UpdateRelayRuleInput{Group: &rule.Group} },
+	)
+}
+
+// SetGroupEnabled enables or disables every rule of a group.
+func (r *RelayRulesResource) SetGroupEnabled(ctx context.Context, relayID, group string, enabled bool) ([]RelayRule, error) {
+	return r.updateGroup(ctx, relayID, group,
+		func(RelayRule) UpdateRelayRuleInput { return UpdateRelayRuleInput{Enabled: &enabled} },
+		func(rule RelayRule) UpdateRelayRuleInput { return UpdateRelayRuleInput{Enabled: &rule.Enabled} },
+	)
+}
+
+// DeleteGroup deletes every rule of a group. It refuses to do so while rules
+// outside the group still branch or escalate to one of them.
+func (r *RelayRulesResource) DeleteGroup(ctx context.Context, relayID, group string) error {
+	rules, err := r.List(ctx, relayID, nil)
+	if err != nil {
+		return err
+	}
+
+	inGroup := make(map[string]bool)
+	for _, rule := range rules {
+		if rule.Group == group {
+			inGroup[rule.ID] = true
+		}
+	}
+	if len(inGroup) == 0 {
+		return groupNotFound(group, relayID)
+	}
+	for _, rule := range rules {
+		if inGroup[rule.ID] {
+			continue
+		}
+		for _, ref := range ruleReferences(rule) {
+			if inGroup[ref] {
+				return &ValidationError{OnCallError: OnCallError{
+					Message: fmt.Sprintf("rule %s outside group %q refers to rule %s in the group", rule.ID, group, ref),
+				}}
+			}
+		}
+	}
+
+	for _, rule := range rules {
+		if !inGroup[rule.ID] {
+			continue
+		}
+		if err := r.Delete(ctx, relayID, rule.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateGroup applies change to every rule of a group. The API has no batch
+// update, so if one update fails the rules already changed are restored with
+// revert, keeping the group all-or-nothing as far as the client can.
+func (r *RelayRulesResource) updateGroup(ctx context.Context, relayID, group string, change, revert func(RelayRule) UpdateRelayRuleInput) ([]RelayRule, error) {
+	rules, err := r.List(ctx, relayID, &ListRelayRulesParams{Group: &group})
+	if err != nil {
+		return nil, err
+	}
+	// Filter again in case the server ignores the group parameter.
+	rules = slices.DeleteFunc(rules, func(rule RelayRule) bool { return rule.Group != group })
+	if len(rules) == 0 {
+		return nil, groupNotFound(group, relayID)
+	}
+	slices.SortStableFunc(rules, func(a, b RelayRule) int {
+		return cmp.Or(cmp.Compare(a.Order, b.Order), strings.Compare(a.ID, b.ID))
+	})
+
+	updated := make([]RelayRule, 0, len(rules))
+	for _, rule := range rules {
+		result, err := r.Update(ctx, relayID, rule.ID, change(rule))
+		if err != nil {
+			var failed rollbackErrors
+			for _, done := range rules[:len(updated)] {
+				if _, rerr := r.Update(ctx, relayID, done.ID, revert(done)); rerr != nil {
+					failed.add(done.ID, rerr)
+				}
+			}
+			return nil, failed.err(err)
+		}
+		updated = append(updated, *result)
+	}
+	return updated, nil
+}
+
+// ruleReferences lists the rule IDs a rule branches or escalates to.
+func ruleReferences(rule RelayRule) []string {
+	var refs []string
+	cfg, _ := rule.TypedConfig()
+	switch cfg := cfg.(type) {
+	case ConditionalConfig:
+		if cfg.TrueRuleID != nil {
+			refs = append(refs, *cfg.TrueRuleID)
+		}
+		if cfg.FalseRuleID != nil {
+			refs = append(refs, *cfg.FalseRuleID)
+		}
+	case EscalateConfig:
+		if cfg.ResetToRuleID != nil {
+			refs = append(refs, *cfg.ResetToRuleID)
+		}
+	}
+	return refs
+}
+
+func groupNotFound(group, relayID string) error {
+	return &NotFoundError{OnCallError: OnCallError{Message: fmt.Sprintf("rule group %q not found in relay %s", group, relayID)}}
+}
+
+func (r *RelayRulesResource) ListGroupsSafe(ctx context.Context, relayID string) Result[[]RuleGroup] {
+	groups, err := r.ListGroups(ctx, relayID)
+	if err != nil {
+		return Result[[]RuleGroup]{Error: err}
+	}
+	return Result[[]RuleGroup]{Data: &groups}
+}
+
+func (r *RelayRulesResource) RenameGroupSafe(ctx context.Context, relayID, from, to string) Result[[]RelayRule] {
+	rules, err := r.RenameGroup(ctx, relayID, from, to)
+	if err != nil {
+		return Result[[]RelayRule]{Error: err}
+	}
+	return Result[[]RelayRule]{Data: &rules}
+}
+
+func (r *RelayRulesResource) SetGroupEnabledSafe(ctx context.Context, relayID, group string, enabled bool) Result[[]RelayRule] {
+	rules, err := r.SetGroupEnabled(ctx, relayID, group, enabled)
+	if err != nil {
+		return Result[[]RelayRule]{Error: err}
+	}
+	return Result[[]RelayRule]{Data: &rules}
+}
+
+func (r *RelayRulesResource) DeleteGroupSafe(ctx context.Context, relayID, group string) Result[bool] {
+	err := r.DeleteGroup(ctx, relayID, group)
+	if err != nil {
+		return Result[bool]{Error: err}
+	}
+	success := true
+	return Result[bool]{Data: &success}
+}
@@ -0,0 +1,265 @@
+package oncall
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// MoveBefore moves a rule directly in front of another rule of the same
+// relay; moving a rule relative to itself is a ValidationError. Like the other
+// reordering helpers it only sends the order changes that are needed, then
+// lists the rules again and returns them in order, failing with
+// ErrRuleOrderMismatch if the server's order differs from the intended one.
+func (r *RelayRulesResource) MoveBefore(ctx context.Context, relayID, ruleID, targetID string) ([]RelayRule, error) {
+	if ruleID == targetID {
+		return nil, moveRelativeToItself(ruleID)
+	}
+	return r.move(ctx, relayID, ruleID, func(ids []string) (int, error) {
+		i := slices.Index(ids, targetID)
+		if i < 0 {
+			return 0, ruleNotInRelay(targetID, relayID)
+		}
+		return i, nil
+	})
+}
+
+// MoveAfter moves a rule directly behind another rule of the same relay.
+func (r *RelayRulesResource) MoveAfter(ctx context.Context, relayID, ruleID, targetID string) ([]RelayRule, error) {
+	if ruleID == targetID {
+		return nil, moveRelativeToItself(ruleID)
+	}
+	return r.move(ctx, relayID, ruleID, func(ids []string) (int, error) {
+		i := slices.Index(ids, targetID)
+		if i < 0 {
+			return 0, ruleNotInRelay(targetID, relayID)
+		}
+		return i + 1, nil
+	})
+}
+
+func (r *RelayRulesResource) MoveToTop(ctx context.Context, relayID, ruleID string) ([]RelayRule, error) {
+	return r.move(ctx, relayID, ruleID, func([]string) (int, error) { return 0, nil })
+}
+
+func (r *RelayRulesResource) MoveToBottom(ctx context.Context, relayID, ruleID string) ([]RelayRule, error) {
+	return r.move(ctx, relayID, ruleID, func(ids []string) (int, error) { return len(ids), nil })
+}
+
+// ReorderGroup puts the rules of a group in the given order. ruleIDs must
+// list every rule of the group exactly once. The group's rules keep the
+// positions the group occupies, so rules of other groups are not moved.
+func (r *RelayRulesResource) ReorderGroup(ctx context.Context, relayID, group string, ruleIDs []string) ([]RelayRule, error) {
+	current, err := r.listOrdered(ctx, relayID)
+	if err != nil {
+		return nil, err
+	}
+
+	var members []string
+	for _, rule := range current {
+		if rule.Group == group {
+			members = append(members, rule.ID)
+		}
+	}
+	if len(ruleIDs) != len(members) || !sameIDs(members, ruleIDs) {
+		return nil, &ValidationError{OnCallError: OnCallError{
+			Message: fmt.Sprintf("rule IDs must list each of the %d rules in group %q exactly once", len(members), group),
+		}}
+	}
+
+	desired := make([]string, 0, len(current))
+	next := 0
+	for _, rule := range current {
+		if rule.Group == group {
+			desired = append(desired, ruleIDs[next])
+			next++
+		} else {
+			desired = append(desired, rule.ID)
+		}
+	}
+	return r.reorderTo(ctx, relayID, current, desired)
+}
+
+// move takes ruleID out of the relay's order and reinserts it at the index
+// returned by position, which sees the order without the moved rule.
+func (r *RelayRulesResource) move(ctx context.Context, relayID, ruleID string, position func(ids []string) (int, error)) ([]RelayRule, error) {
+	current, err := r.listOrdered(ctx, relayID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(current))
+	found := false
+	for _, rule := range current {
+		if rule.ID == ruleID {
+			found = true
+			continue
+		}
+		ids = append(ids, rule.ID)
+	}
+	if !found {
+		return nil, ruleNotInRelay(ruleID, relayID)
+	}
+
+	i, err := position(ids)
+	if err != nil {
+		return nil, err
+	}
+	return r.reorderTo(ctx, relayID, current, slices.Insert(ids, i, ruleID))
+}
+
+func (r *RelayRulesResource) listOrdered(ctx context.Context, relayID string) ([]RelayRule, error) {
+	rules, err := r.List(ctx, relayID, nil)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(rules, func(a, b RelayRule) int {
+		return cmp.Or(cmp.Compare(a.Order, b.Order), strings.Compare(a.ID, b.ID))
+	})
+	return rules, nil
+}
+
+func (r *RelayRulesResource) reorderTo(ctx context.Context, relayID string, current []RelayRule, desired []string) ([]RelayRule, error) {
+	changes := ruleOrderChanges(current, desired)
+	if len(changes) == 0 {
+		return current, nil
+	}
+	if _, err := r.Reorder(ctx, relayID, ReorderRelayRulesInput{Rules: changes}); err != nil {
+		return nil, err
+	}
+
+	after, err := r.listOrdered(ctx, relayID)
+	if err != nil {
+		return nil, err
+	}
+	got := make([]string, len(after))
+	for i, rule := range after {
+		got[i] = rule.ID
+	}
+	if !slices.Equal(got, desired) {
+		return after, fmt.Errorf("%w: expected %s, got %s", ErrRuleOrderMismatch, strings.Join(desired, ", "), strings.Join(got, ", "))
+	}
+	return after, nil
+}
+
+// ruleOrderChanges returns the fewest order changes that arrange current,
+// sorted by Order, in the desired sequence of rule IDs.
+//
+// A set of rules can keep their orders if there is room between each pair of
+// them for the rules the desired sequence puts in between, that is if
+// order-position never decreases along the set. The largest such set is a
+// longest non-decreasing subsequence; every other rule gets the lowest order
+// after its predecessor. Orders may drop below the current minimum but never
+// below zero, as Create and Update reject negative orders; a rule that has a
+// negative order is always given a new one.
+func ruleOrderChanges(current []RelayRule, desired []string) []RuleOrder {
+	if len(desired) == 0 {
+		return nil
+	}
+	orders := make(map[string]int, len(current))
+	for _, rule := range current {
+		orders[rule.ID] = rule.Order
+	}
+
+	n := len(desired)
+	slack := make([]int, n)
+	for i, id := range desired {
+		slack[i] = orders[id] - i
+	}
+
+	// length[i] is the size of the longest keepable set ending at i.
+	length := make([]int, n)
+	prev := make([]int, n)
+	best := -1
+	for i := range desired {
+		prev[i] = -1
+		if slack[i] < 0 {
+			continue
+		}
+		length[i] = 1
+		for j := 0; j < i; j++ {
+			if length[j] > 0 && slack[j] <= slack[i] && length[j]+1 > length[i] {
+				length[i] = length[j] + 1
+				prev[i] = j
+			}
+		}
+		if best < 0 || length[i] > length[best] {
+			best = i
+		}
+	}
+	keep := make([]bool, n)
+	for i := best; i >= 0; i = prev[i] {
+		keep[i] = true
+	}
+
+	var changes []RuleOrder
+	order := -1
+	for i, id := range desired {
+		if keep[i] {
+			order = orders[id]
+			continue
+		}
+		order++
+		if order != orders[id] {
+			changes = append(changes, RuleOrder{ID: id, Order: order})
+		}
+	}
+	return changes
+}
+
+func sameIDs(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
+
+func moveRelativeToItself(ruleID string) error {
+	return &ValidationError{OnCallError: OnCallError{Message: fmt.Sprintf("cannot move rule %s relative to itself", ruleID)}}
+}
+
+func ruleNotInRelay(ruleID, relayID string) error {
+	return &NotFoundError{OnCallError: OnCallError{Message: fmt.Sprintf("rule %s not found in relay %s", ruleID, relayID)}}
+}
+
+func (r *RelayRulesResource) MoveBeforeSafe(ctx context.Context, relayID, ruleID, targetID string) Result[[]RelayRule] {
+	rules, err := r.MoveBefore(ctx, relayID, ruleID, targetID)
+	if err != nil {
+		return Result[[]RelayRule]{Error: err}
+	}
+	return Result[[]RelayRule]{Data: &rules}
+}
+
+func (r *RelayRulesResource) MoveAfterSafe(ctx context.Context, relayID, ruleID, targetID string) Result[[]RelayRule] {
+	rules, err := r.MoveAfter(ctx, relayID, ruleID, targetID)
+	if err != nil {
+		return Result[[]RelayRule]{Error: err}
+	}
+	return Result[[]RelayRule]{Data: &rules}
+}
+
+func (r *RelayRulesResource) MoveToTopSafe(ctx context.Context, relayID, ruleID string) Result[[]RelayRule] {
+	rules, err := r.MoveToTop(ctx, relayID, ruleID)
+	if err != nil {
+		return Result[[]RelayRule]{Error: err}
+	}
+	return Result[[]RelayRule]{Data: &rules}
+}
+
+func (r *RelayRulesResource) MoveToBottomSafe(ctx context.Context, relayID, ruleID string) Result[[]RelayRule] {
+	rules, err := r.MoveToBottom(ctx, relayID, ruleID)
+	if err != nil {
+		return Result[[]RelayRule]{Error: err}
+	}
+	return Result[[]RelayRule]{Data: &rules}
+}
+
+func (r *RelayRulesResource) ReorderGroupSafe(ctx context.Context, relayID, group string, ruleIDs []string) Result[[]RelayRule] {
+	rules, err := r.ReorderGroup(ctx, relayID, group, ruleIDs)
+	if err != nil {
+		return Result[[]RelayRule]{Error: err}
+	}
+	return Result[[]RelayRule]{Data: &rules}
+}
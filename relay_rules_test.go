@@ -0,0 +1,333 @@
+package oncall
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeRules is an in-memory stand-in for the relay rules endpoints.
+type fakeRules struct {
+	mu     sync.Mutex
+	rules  []RelayRule
+	nextID int
+}
+
+func (f *fakeRules) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		if len(parts) < 3 || parts[0] != "relay" || parts[2] != "rules" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		relayID := parts[1]
+
+		switch {
+		case len(parts) == 3 && r.Method == http.MethodGet:
+			var rules []RelayRule
+			for _, rule := range f.rules {
+				if group := r.URL.Query().Get("group"); group != "" && rule.Group != group {
+					continue
+				}
+				rules = append(rules, rule)
+			}
+			json.NewEncoder(w).Encode(map[string]any{"rules": rules})
+		case len(parts) == 3 && r.Method == http.MethodPost:
+			var input CreateRelayRuleInput
+			json.NewDecoder(r.Body).Decode(&input)
+			f.nextID++
+			rule := RelayRule{
+				ID:          fmt.Sprintf("rule%d", f.nextID),
+				RelayID:     relayID,
+				Name:        input.Name,
+				RuleType:    input.RuleType,
+				Config:      input.Config,
+				ExternalKey: input.ExternalKey,
+				Enabled:     input.Enabled == nil || *input.Enabled,
+			}
+			if input.Group != nil {
+				rule.Group = *input.Group
+			}
+			if input.Order != nil {
+				rule.Order = *input.Order
+			}
+			f.rules = append(f.rules, rule)
+			json.NewEncoder(w).Encode(map[string]any{"rule": rule})
+		case len(parts) == 4 && parts[3] == "reorder" && r.Method == http.MethodPut:
+			var input ReorderRelayRulesInput
+			json.NewDecoder(r.Body).Decode(&input)
+			for _, change := range input.Rules {
+				if i := f.find(change.ID); i >= 0 {
+					f.rules[i].Order = change.Order
+				}
+			}
+			json.NewEncoder(w).Encode(map[string]any{"rules": f.rules})
+		case len(parts) == 4 && r.Method == http.MethodPut:
+			i := f.find(parts[3])
+			if i < 0 {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			var input UpdateRelayRuleInput
+			json.NewDecoder(r.Body).Decode(&input)
+			rule := &f.rules[i]
+			if input.Name != nil {
+				rule.Name = *input.Name
+			}
+			if input.RuleType != nil {
+				rule.RuleType = *input.RuleType
+			}
+			if input.Group != nil {
+				rule.Group = *input.Group
+			}
+			if input.Order != nil {
+				rule.Order = *input.Order
+			}
+			if input.Config != nil {
+				rule.Config = input.Config
+			}
+			if input.Enabled != nil {
+				rule.Enabled = *input.Enabled
+			}
+			json.NewEncoder(w).Encode(map[string]any{"rule": rule})
+		case len(parts) == 4 && r.Method == http.MethodDelete:
+			i := f.find(parts[3])
+			if i < 0 {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			f.rules = slices.Delete(f.rules, i, i+1)
+			w.Write([]byte(`{"success":true}`))
+		default:
+			t.Fatalf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	}
+}
+
+func (f *fakeRules) find(id string) int {
+	return slices.IndexFunc(f.rules, func(r RelayRule) bool { return r.ID == id })
+}
+
+func TestUpsertRelayRuleByExternalKey(t *testing.T) {
+	fake := &fakeRules{}
+	client := newTestClient(t, fake.handler(t))
+	ctx := context.Background()
+
+	key := "wait"
+	input := CreateRelayRuleInput{ExternalKey: &key, Name: "Wait", TypedConfig: WaitConfig{Duration: 300}}
+
+	for _, want := range []UpsertAction{UpsertCreated, UpsertUnchanged} {
+		result, err := client.Relay.Rules.UpsertByExternalKey(ctx, "relay1", input)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Action != want {
+			t.Fatalf("expected %s, got %s", want, result.Action)
+		}
+	}
+
+	input.TypedConfig = WaitConfig{Duration: 600}
+	result, err := client.Relay.Rules.UpsertByExternalKey(ctx, "relay1", input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Action != UpsertUpdated || result.Data.Config["duration"] != float64(600) {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if len(fake.rules) != 1 {
+		t.Fatalf("expected a single rule, got %d", len(fake.rules))
+	}
+
+	result, err = client.Relay.Rules.UpsertByExternalKey(ctx, "relay1", CreateRelayRuleInput{ExternalKey: &key, Name: "Wait"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Action != UpsertUnchanged || result.Data.Config["duration"] != float64(600) {
+		t.Fatalf("expected a nil config to leave the rule unchanged, got %+v", result)
+	}
+
+	var verr *ValidationError
+	if _, err := client.Relay.Rules.UpsertByExternalKey(ctx, "relay1", CreateRelayRuleInput{Name: "no key"}); !errors.As(err, &verr) {
+		t.Fatalf("expected ValidationError without external key, got %v", err)
+	}
+}
+
+func TestRuleOrderChanges(t *testing.T) {
+	current := []RelayRule{{ID: "a", Order: 1}, {ID: "b", Order: 2}, {ID: "c", Order: 3}, {ID: "d", Order: 4}, {ID: "e", Order: 5}}
+
+	tests := []struct {
+		desired []string
+		want    []RuleOrder
+	}{
+		{[]string{"a", "b", "c", "d", "e"}, nil},
+		{[]string{"e", "a", "b", "c", "d"}, []RuleOrder{{"e", 0}}},
+		{[]string{"b", "c", "d", "e", "a"}, []RuleOrder{{"a", 6}}},
+		{[]string{"a", "c", "b", "d", "e"}, []RuleOrder{{"c", 2}, {"b", 3}}},
+	}
+	for _, tt := range tests {
+		if got := ruleOrderChanges(current, tt.desired); !slices.Equal(got, tt.want) {
+			t.Errorf("%v: expected %v, got %v", tt.desired, tt.want, got)
+		}
+	}
+
+	negative := []RelayRule{{ID: "a", Order: -2}, {ID: "b", Order: 5}}
+	if got := ruleOrderChanges(negative, []string{"a", "b"}); !slices.Equal(got, []RuleOrder{{"a", 0}}) {
+		t.Errorf("expected negative orders to be raised to zero, got %v", got)
+	}
+
+	sparse := []RelayRule{{ID: "a", Order: 10}, {ID: "b", Order: 20}, {ID: "c", Order: 30}}
+	if got := ruleOrderChanges(sparse, []string{"a", "c", "b"}); !slices.Equal(got, []RuleOrder{{"b", 31}}) {
+		t.Errorf("expected a single change for sparse orders, got %v", got)
+	}
+}
+
+func TestMoveRules(t *testing.T) {
+	fake := &fakeRules{rules: []RelayRule{
+		{ID: "a", Order: 1, Group: "day"},
+		{ID: "b", Order: 2, Group: "night"},
+		{ID: "c", Order: 3, Group: "day"},
+		{ID: "d", Order: 4, Group: "night"},
+	}}
+	client := newTestClient(t, fake.handler(t))
+	ctx := context.Background()
+
+	ids := func(rules []RelayRule) string {
+		var out []string
+		for _, rule := range rules {
+			out = append(out, rule.ID)
+		}
+		return strings.Join(out, ",")
+	}
+
+	rules, err := client.Relay.Rules.MoveBefore(ctx, "relay1", "d", "b")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := ids(rules); got != "a,d,b,c" {
+		t.Fatalf("unexpected order after MoveBefore: %s", got)
+	}
+
+	rules, err = client.Relay.Rules.ReorderGroup(ctx, "relay1", "day", []string{"c", "a"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := ids(rules); got != "c,d,b,a" {
+		t.Fatalf("unexpected order after ReorderGroup: %s", got)
+	}
+
+	var nf *NotFoundError
+	if _, err := client.Relay.Rules.MoveToTop(ctx, "relay1", "missing"); !errors.As(err, &nf) {
+		t.Fatalf("expected NotFoundError, got %v", err)
+	}
+	var verr *ValidationError
+	if _, err := client.Relay.Rules.ReorderGroup(ctx, "relay1", "day", []string{"c"}); !errors.As(err, &verr) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	if _, err := client.Relay.Rules.MoveBefore(ctx, "relay1", "b", "b"); !errors.As(err, &verr) {
+		t.Fatalf("expected ValidationError moving a rule before itself, got %v", err)
+	}
+	if _, err := client.Relay.Rules.MoveAfter(ctx, "relay1", "b", "b"); !errors.As(err, &verr) {
+		t.Fatalf("expected ValidationError moving a rule after itself, got %v", err)
+	}
+}
+
+func TestRuleGroups(t *testing.T) {
+	reset := "b"
+	fake := &fakeRules{rules: []RelayRule{
+		{ID: "a", Order: 3, Group: "night", Enabled: true, RuleType: RuleTypeWait, Config: map[string]any{"duration": 60}},
+		{ID: "b", Order: 1, Group: "day", Enabled: true, RuleType: RuleTypeWait, Config: map[string]any{"duration": 60}},
+		{ID: "c", Order: 2, Group: "day", Enabled: true, RuleType: RuleTypeWait, Config: map[string]any{"duration": 60}},
+		{ID: "d", Order: 4, Group: "night", Enabled: true, RuleType: RuleTypeEscalate, Config: map[string]any{"maxAttempts": 1, "resetToRuleId": reset}},
+	}}
+	failOn := ""
+	handler := fake.handler(t)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/"+failOn) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"rejected"}`))
+			return
+		}
+		handler(w, r)
+	})
+	ctx := context.Background()
+
+	groups, err := client.Relay.Rules.ListGroups(ctx, "relay1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(groups) != 2 || groups[0].Name != "day" || groups[0].Rules[0].ID != "b" || groups[1].Name != "night" {
+		t.Fatalf("unexpected groups: %+v", groups)
+	}
+
+	failOn = "c"
+	if _, err := client.Relay.Rules.SetGroupEnabled(ctx, "relay1", "day", false); err == nil {
+		t.Fatal("expected error when an update fails")
+	}
+	if !fake.rules[fake.find("b")].Enabled {
+		t.Fatal("expected rule b to be re-enabled after the failed group update")
+	}
+
+	failOn = ""
+	rules, err := client.Relay.Rules.RenameGroup(ctx, "relay1", "day", "business-hours")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rules) != 2 || rules[0].Group != "business-hours" {
+		t.Fatalf("unexpected rules after rename: %+v", rules)
+	}
+
+	var verr *ValidationError
+	if err := client.Relay.Rules.DeleteGroup(ctx, "relay1", "business-hours"); !errors.As(err, &verr) {
+		t.Fatalf("expected ValidationError for referenced group, got %v", err)
+	}
+	if err := client.Relay.Rules.DeleteGroup(ctx, "relay1", "night"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(fake.rules) != 2 {
+		t.Fatalf("expected 2 rules left, got %d", len(fake.rules))
+	}
+	var nf *NotFoundError
+	if err := client.Relay.Rules.DeleteGroup(ctx, "relay1", "night"); !errors.As(err, &nf) {
+		t.Fatalf("expected NotFoundError, got %v", err)
+	}
+}
+
+func TestUpdateGroupRollback(t *testing.T) {
+	fake := &fakeRules{rules: []RelayRule{
+		{ID: "a", Order: 1, Group: "day", Enabled: true},
+		{ID: "b", Order: 2, Group: "day", Enabled: true},
+		{ID: "c", Order: 3, Group: "day", Enabled: true},
+	}}
+	handler := fake.handler(t)
+	puts := make(map[string]int)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+			puts[id]++
+			// c cannot be updated, and a cannot be reverted.
+			if id == "c" || (id == "a" && puts[id] == 2) {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":"rejected"}`))
+				return
+			}
+		}
+		handler(w, r)
+	})
+
+	_, err := client.Relay.Rules.SetGroupEnabled(context.Background(), "relay1", "day", false)
+	var verr *ValidationError
+	if !errors.As(err, &verr) || !strings.Contains(err.Error(), "left behind: a") {
+		t.Fatalf("expected the update error naming rule a, got %v", err)
+	}
+	if !fake.rules[fake.find("b")].Enabled {
+		t.Fatal("expected rule b to be reverted after rule a failed to revert")
+	}
+}
@@ -0,0 +1,72 @@
+package oncall
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestUpsertRelayByExternalKey(t *testing.T) {
+	var relays []Relay
+	var updates []map[string]any
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/relay":
+			json.NewEncoder(w).Encode(map[string]any{"relays": relays})
+		case r.Method == http.MethodPost && r.URL.Path == "/relay":
+			var input CreateRelayInput
+			json.NewDecoder(r.Body).Decode(&input)
+			relay := Relay{ID: "relay1", Name: input.Name, ExternalKey: input.ExternalKey}
+			if input.Description != nil {
+				relay.Description = *input.Description
+			}
+			relays = append(relays, relay)
+			json.NewEncoder(w).Encode(map[string]any{"relay": relay})
+		case r.Method == http.MethodPut && r.URL.Path == "/relay/relay1":
+			var input map[string]any
+			json.NewDecoder(r.Body).Decode(&input)
+			updates = append(updates, input)
+			if name, ok := input["name"].(string); ok {
+				relays[0].Name = name
+			}
+			json.NewEncoder(w).Encode(map[string]any{"relay": relays[0]})
+		default:
+			t.Fatalf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	})
+	ctx := context.Background()
+
+	key, description := "payments", "Payments team"
+	input := CreateRelayInput{Name: "Payments", Description: &description, ExternalKey: &key}
+	for _, want := range []UpsertAction{UpsertCreated, UpsertUnchanged} {
+		result, err := client.Relay.UpsertByExternalKey(ctx, input)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Action != want || result.Data.ID != "relay1" {
+			t.Fatalf("expected %s of relay1, got %+v", want, result)
+		}
+	}
+	if len(relays) != 1 || len(updates) != 0 {
+		t.Fatalf("expected one relay and no updates, got %d and %d", len(relays), len(updates))
+	}
+
+	input.Name = "Payments (EU)"
+	result, err := client.Relay.UpsertByExternalKey(ctx, input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Action != UpsertUpdated || result.Data.Name != "Payments (EU)" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if len(updates) != 1 || len(updates[0]) != 1 || updates[0]["name"] != "Payments (EU)" {
+		t.Fatalf("expected an update of the name only, got %v", updates)
+	}
+
+	var verr *ValidationError
+	if _, err := client.Relay.UpsertByExternalKey(ctx, CreateRelayInput{Name: "no key"}); !errors.As(err, &verr) {
+		t.Fatalf("expected ValidationError without external key, got %v", err)
+	}
+}
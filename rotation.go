@@ -0,0 +1,133 @@
+package oncall
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// The rotation rules below are this package's model of a schedule, not a
+// description of the server's; they have not been checked against assignments
+// the API returns, so use Schedule.GetAssignments where the answer matters.
+//
+// Rotations are computed in UTC. A schedule's first shift is the daily or
+// weekly period, starting at StartTime (on StartDay for weekly schedules),
+// that contains the schedule's CreatedAt. Shifts are numbered from 0 by
+// AssignmentNumber, and shift n goes to the member at position n modulo the
+// number of members, in Order. A schedule with an unknown Type, or a weekly
+// schedule with an unknown StartDay, is a ValidationError.
+
+// AssignmentAt returns the assignment covering t without calling the API.
+// Times before the first shift belong to the first shift.
+func AssignmentAt(schedule Schedule, members []ScheduleMember, t time.Time) (ScheduleAssignment, error) {
+	r, err := newRotation(schedule, members)
+	if err != nil {
+		return ScheduleAssignment{}, err
+	}
+	return r.assignment(r.shiftAt(t)), nil
+}
+
+// ComputeAssignments returns the assignments overlapping [from, to) without
+// calling the API, as the same ScheduleAssignment values
+// Schedule.GetAssignments returns.
+// Overrides are not taken into account.
+func ComputeAssignments(schedule Schedule, members []ScheduleMember, from, to time.Time) ([]ScheduleAssignment, error) {
+	r, err := newRotation(schedule, members)
+	if err != nil {
+		return nil, err
+	}
+	if !to.After(from) {
+		return nil, nil
+	}
+
+	var assignments []ScheduleAssignment
+	for n := r.shiftAt(from); r.start(n).Before(to); n++ {
+		assignments = append(assignments, r.assignment(n))
+	}
+	return assignments, nil
+}
+
+type rotation struct {
+	members []ScheduleMember
+	anchor  time.Time
+	period  time.Duration
+}
+
+func newRotation(schedule Schedule, members []ScheduleMember) (*rotation, error) {
+	if len(members) == 0 {
+		return nil, fmt.Errorf("schedule %s has no members", schedule.ID)
+	}
+	var errs fieldErrors
+	if schedule.Type != ScheduleTypeDaily && schedule.Type != ScheduleTypeWeekly {
+		errs.add("type", "unknown schedule type %q", schedule.Type)
+	}
+	startDay, ok := weekday(schedule.StartDay)
+	if schedule.Type == ScheduleTypeWeekly && !ok {
+		errs.add("startDay", "unknown day %q", schedule.StartDay)
+	}
+	if err := schedule.StartTime.Validate(); err != nil {
+		errs.add("startTime", "%s", err)
+	}
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+
+	r := &rotation{
+		members: slices.Clone(members),
+		period:  24 * time.Hour,
+	}
+	slices.SortStableFunc(r.members, func(a, b ScheduleMember) int { return a.Order - b.Order })
+
+	created := schedule.CreatedAt.UTC()
+	r.anchor = schedule.StartTime.On(created, time.UTC)
+	if schedule.Type == ScheduleTypeWeekly {
+		r.period = 7 * r.period
+		for r.anchor.Weekday() != startDay {
+			r.anchor = r.anchor.AddDate(0, 0, -1)
+		}
+	}
+	if r.anchor.After(created) {
+		r.anchor = r.anchor.Add(-r.period)
+	}
+	return r, nil
+}
+
+func (r *rotation) shiftAt(t time.Time) int {
+	if !t.After(r.anchor) {
+		return 0
+	}
+	return int(t.Sub(r.anchor) / r.period)
+}
+
+func (r *rotation) start(n int) time.Time {
+	return r.anchor.Add(time.Duration(n) * r.period)
+}
+
+func (r *rotation) assignment(n int) ScheduleAssignment {
+	return ScheduleAssignment{
+		UserID:           r.members[n%len(r.members)].UserID,
+		StartDate:        r.start(n).Format(time.RFC3339),
+		EndDate:          r.start(n + 1).Format(time.RFC3339),
+		AssignmentNumber: n,
+	}
+}
+
+func weekday(d DayOfWeek) (time.Weekday, bool) {
+	switch d {
+	case Sunday:
+		return time.Sunday, true
+	case Monday:
+		return time.Monday, true
+	case Tuesday:
+		return time.Tuesday, true
+	case Wednesday:
+		return time.Wednesday, true
+	case Thursday:
+		return time.Thursday, true
+	case Friday:
+		return time.Friday, true
+	case Saturday:
+		return time.Saturday, true
+	}
+	return 0, false
+}
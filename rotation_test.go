@@ -0,0 +1,209 @@
+package oncall
+
+import (
+	"context"
+	"errors"
+	"os"
+	"slices"
+	"testing"
+	"time"
+)
+
+// The expected assignments below are worked out by hand from the rotation
+// rules documented in rotation.go, not recorded from the API, so they only
+// check that the code follows those rules.
+// TestComputeAssignmentsAgainstAPI compares with a real schedule when
+// ONCALL_API_KEY and ONCALL_SCHEDULE_ID are set.
+func TestComputeAssignments(t *testing.T) {
+	shift := func(n int, userID, start, end string) ScheduleAssignment {
+		return ScheduleAssignment{UserID: userID, StartDate: start, EndDate: end, AssignmentNumber: n}
+	}
+	edt := time.FixedZone("EDT", -4*60*60)
+
+	tests := []struct {
+		name     string
+		schedule Schedule
+		members  []ScheduleMember
+		from, to time.Time
+		want     []ScheduleAssignment
+	}{
+		{
+			// Created on a Wednesday: the first shift started on the Monday before.
+			name: "weekly",
+			schedule: Schedule{
+				ID: "weekly", Type: ScheduleTypeWeekly, StartDay: Monday, StartTime: TimeOfDay{Hour: 9},
+				CreatedAt: time.Date(2026, 1, 7, 15, 30, 0, 0, time.UTC),
+			},
+			members: []ScheduleMember{{UserID: "carol", Order: 2}, {UserID: "alice", Order: 0}, {UserID: "bob", Order: 1}},
+			from:    time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			to:      time.Date(2026, 2, 2, 9, 0, 0, 0, time.UTC),
+			want: []ScheduleAssignment{
+				shift(0, "alice", "2026-01-05T09:00:00Z", "2026-01-12T09:00:00Z"),
+				shift(1, "bob", "2026-01-12T09:00:00Z", "2026-01-19T09:00:00Z"),
+				shift(2, "carol", "2026-01-19T09:00:00Z", "2026-01-26T09:00:00Z"),
+				shift(3, "alice", "2026-01-26T09:00:00Z", "2026-02-02T09:00:00Z"),
+			},
+		},
+		{
+			// Created at 06:00, before the 08:30 start: the first shift began the day before.
+			name: "daily created before start time",
+			schedule: Schedule{
+				ID: "daily", Type: ScheduleTypeDaily, StartDay: Monday, StartTime: TimeOfDay{Hour: 8, Minute: 30},
+				CreatedAt: time.Date(2026, 3, 10, 6, 0, 0, 0, time.UTC),
+			},
+			members: []ScheduleMember{{UserID: "alice", Order: 0}, {UserID: "bob", Order: 1}},
+			from:    time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC),
+			to:      time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC),
+			want: []ScheduleAssignment{
+				shift(2, "alice", "2026-03-11T08:30:00Z", "2026-03-12T08:30:00Z"),
+				shift(3, "bob", "2026-03-12T08:30:00Z", "2026-03-13T08:30:00Z"),
+				shift(4, "alice", "2026-03-13T08:30:00Z", "2026-03-14T08:30:00Z"),
+			},
+		},
+		{
+			// Created on the start day itself but before the start time: the
+			// first shift began a week earlier.
+			name: "weekly created before start time",
+			schedule: Schedule{
+				ID: "weekly-early", Type: ScheduleTypeWeekly, StartDay: Monday, StartTime: TimeOfDay{Hour: 9},
+				CreatedAt: time.Date(2026, 1, 5, 7, 0, 0, 0, time.UTC),
+			},
+			members: []ScheduleMember{{UserID: "alice", Order: 0}, {UserID: "bob", Order: 1}},
+			from:    time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC),
+			to:      time.Date(2026, 1, 12, 12, 0, 0, 0, time.UTC),
+			want: []ScheduleAssignment{
+				shift(0, "alice", "2025-12-29T09:00:00Z", "2026-01-05T09:00:00Z"),
+				shift(1, "bob", "2026-01-05T09:00:00Z", "2026-01-12T09:00:00Z"),
+				shift(2, "alice", "2026-01-12T09:00:00Z", "2026-01-19T09:00:00Z"),
+			},
+		},
+		{
+			// Created on a Tuesday with Friday as the start day: the first
+			// shift began on the Friday before.
+			name: "weekly start day later in the week",
+			schedule: Schedule{
+				ID: "weekly-friday", Type: ScheduleTypeWeekly, StartDay: Friday, StartTime: TimeOfDay{Hour: 18},
+				CreatedAt: time.Date(2026, 1, 6, 10, 0, 0, 0, time.UTC),
+			},
+			members: []ScheduleMember{{UserID: "alice", Order: 0}, {UserID: "bob", Order: 1}, {UserID: "carol", Order: 2}},
+			from:    time.Date(2026, 1, 6, 10, 0, 0, 0, time.UTC),
+			to:      time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC),
+			want: []ScheduleAssignment{
+				shift(0, "alice", "2026-01-02T18:00:00Z", "2026-01-09T18:00:00Z"),
+				shift(1, "bob", "2026-01-09T18:00:00Z", "2026-01-16T18:00:00Z"),
+				shift(2, "carol", "2026-01-16T18:00:00Z", "2026-01-23T18:00:00Z"),
+			},
+		},
+		{
+			// 22:00 EDT on March 9 is 02:00 UTC on March 10, so the first shift
+			// starts at 01:00 UTC on March 10, not on the local date.
+			name: "created outside UTC",
+			schedule: Schedule{
+				ID: "daily-edt", Type: ScheduleTypeDaily, StartTime: TimeOfDay{Hour: 1},
+				CreatedAt: time.Date(2026, 3, 9, 22, 0, 0, 0, edt),
+			},
+			members: []ScheduleMember{{UserID: "alice", Order: 0}, {UserID: "bob", Order: 1}},
+			from:    time.Date(2026, 3, 9, 22, 0, 0, 0, edt),
+			to:      time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC),
+			want: []ScheduleAssignment{
+				shift(0, "alice", "2026-03-10T01:00:00Z", "2026-03-11T01:00:00Z"),
+				shift(1, "bob", "2026-03-11T01:00:00Z", "2026-03-12T01:00:00Z"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ComputeAssignments(tt.schedule, tt.members, tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("unexpected assignments:\nwant %+v\ngot  %+v", tt.want, got)
+			}
+			checkAssignmentAt(t, tt.schedule, tt.members, tt.want)
+		})
+	}
+}
+
+func TestComputeAssignmentsValidation(t *testing.T) {
+	members := []ScheduleMember{{UserID: "alice"}}
+	now := time.Now()
+
+	if _, err := ComputeAssignments(Schedule{ID: "empty", Type: ScheduleTypeDaily}, nil, now, now.Add(time.Hour)); err == nil {
+		t.Fatal("expected error for a schedule without members")
+	}
+
+	invalid := map[string]Schedule{
+		"unknown type":       {Type: "monthly"},
+		"unknown weekly day": {Type: ScheduleTypeWeekly, StartDay: "someday"},
+		"missing weekly day": {Type: ScheduleTypeWeekly},
+		"out of range time":  {Type: ScheduleTypeDaily, StartTime: TimeOfDay{Hour: 24}},
+	}
+	for name, schedule := range invalid {
+		var verr *ValidationError
+		if _, err := AssignmentAt(schedule, members, now); !errors.As(err, &verr) {
+			t.Errorf("%s: expected ValidationError, got %v", name, err)
+		}
+	}
+
+	if _, err := AssignmentAt(Schedule{Type: ScheduleTypeDaily}, members, now); err != nil {
+		t.Fatalf("daily schedules need no start day: %v", err)
+	}
+}
+
+// TestComputeAssignmentsAgainstAPI compares the local rotation with the
+// assignments the API reports for a real schedule.
+func TestComputeAssignmentsAgainstAPI(t *testing.T) {
+	apiKey, scheduleID := os.Getenv("ONCALL_API_KEY"), os.Getenv("ONCALL_SCHEDULE_ID")
+	if apiKey == "" || scheduleID == "" {
+		t.Skip("set ONCALL_API_KEY and ONCALL_SCHEDULE_ID to compare with a real schedule")
+	}
+	client, err := NewClient(Config{APIKey: apiKey, BaseURL: os.Getenv("ONCALL_BASE_URL")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx := context.Background()
+
+	schedule, err := client.Schedule.Get(ctx, scheduleID)
+	if err != nil {
+		t.Fatalf("get schedule: %v", err)
+	}
+	members, err := client.Schedule.ListMembers(ctx, scheduleID)
+	if err != nil {
+		t.Fatalf("list members: %v", err)
+	}
+	assignments, err := client.Schedule.GetAssignments(ctx, scheduleID, nil)
+	if err != nil {
+		t.Fatalf("get assignments: %v", err)
+	}
+	assignments = slices.DeleteFunc(assignments, func(a ScheduleAssignment) bool { return a.IsOverride })
+	if len(assignments) == 0 {
+		t.Skip("schedule has no rotation assignments")
+	}
+	checkAssignmentAt(t, *schedule, members, assignments)
+}
+
+// checkAssignmentAt compares dates as instants, parsed like the schedule
+// override code does, since the API may send RFC 3339 times or bare dates.
+func checkAssignmentAt(t *testing.T, schedule Schedule, members []ScheduleMember, want []ScheduleAssignment) {
+	t.Helper()
+	parse := func(s string) time.Time {
+		at, err := parseAssignmentTime(s)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return at
+	}
+	for _, a := range want {
+		start := parse(a.StartDate)
+		got, err := AssignmentAt(schedule, members, start)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.UserID != a.UserID || got.AssignmentNumber != a.AssignmentNumber ||
+			!parse(got.StartDate).Equal(start) || !parse(got.EndDate).Equal(parse(a.EndDate)) {
+			t.Fatalf("AssignmentAt(%s) = %+v, want %+v", a.StartDate, got, a)
+		}
+	}
+}
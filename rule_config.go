@@ -0,0 +1,150 @@
+package oncall
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// RuleConfig is implemented by the typed config structs of each relay rule
+// type. Setting CreateRelayRuleInput.TypedConfig or
+// UpdateRelayRuleInput.TypedConfig fills in Config and RuleType from it.
+type RuleConfig interface {
+	RuleType() RelayRuleType
+}
+
+func (ScheduleNotifyConfig) RuleType() RelayRuleType { return RuleTypeScheduleNotify }
+func (WebhookConfig) RuleType() RelayRuleType        { return RuleTypeWebhook }
+func (AgentConfig) RuleType() RelayRuleType          { return RuleTypeAgent }
+func (ExternalApiConfig) RuleType() RelayRuleType    { return RuleTypeExternalAPI }
+func (WaitConfig) RuleType() RelayRuleType           { return RuleTypeWait }
+func (ConditionalConfig) RuleType() RelayRuleType    { return RuleTypeConditional }
+func (EscalateConfig) RuleType() RelayRuleType       { return RuleTypeEscalate }
+
+// TypedConfig decodes Config into the struct matching RuleType.
+func (r RelayRule) TypedConfig() (RuleConfig, error) {
+	switch r.RuleType {
+	case RuleTypeScheduleNotify:
+		return decodeRuleConfig[ScheduleNotifyConfig](r)
+	case RuleTypeWebhook:
+		return decodeRuleConfig[WebhookConfig](r)
+	case RuleTypeAgent:
+		return decodeRuleConfig[AgentConfig](r)
+	case RuleTypeExternalAPI:
+		return decodeRuleConfig[ExternalApiConfig](r)
+	case RuleTypeWait:
+		return decodeRuleConfig[WaitConfig](r)
+	case RuleTypeConditional:
+		return decodeRuleConfig[ConditionalConfig](r)
+	case RuleTypeEscalate:
+		return decodeRuleConfig[EscalateConfig](r)
+	default:
+		return nil, fmt.Errorf("rule %s has unknown rule type %q", r.ID, r.RuleType)
+	}
+}
+
+// RuleConfigAs decodes a rule's config into T, failing if T does not belong
+// to the rule's type. T must be a config struct such as WaitConfig, not a
+// pointer to one.
+func RuleConfigAs[T RuleConfig](r RelayRule) (T, error) {
+	var zero T
+	if reflect.TypeFor[T]().Kind() == reflect.Pointer {
+		return zero, fmt.Errorf("RuleConfigAs needs a config struct type, not %T", zero)
+	}
+	if zero.RuleType() != r.RuleType {
+		return zero, fmt.Errorf("rule %s is a %s rule, not %s", r.ID, r.RuleType, zero.RuleType())
+	}
+	return decodeRuleConfig[T](r)
+}
+
+// RuleConfigEqual compares rule configs by their JSON encoding, so that
+// numbers decoded from the API compare equal to the ints of a typed config.
+func RuleConfigEqual(a, b map[string]any) bool {
+	x, errX := json.Marshal(a)
+	y, errY := json.Marshal(b)
+	return errX == nil && errY == nil && bytes.Equal(x, y)
+}
+
+// nilRuleConfig reports whether cfg is a nil pointer to a config struct. Such
+// a value satisfies RuleConfig, but calling its methods panics.
+func nilRuleConfig(cfg RuleConfig) bool {
+	v := reflect.ValueOf(cfg)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
+
+func decodeRuleConfig[T RuleConfig](r RelayRule) (T, error) {
+	var cfg T
+	data, err := json.Marshal(r.Config)
+	if err != nil {
+		return cfg, fmt.Errorf("failed to encode config of rule %s: %w", r.ID, err)
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("config of rule %s does not match %s: %w", r.ID, r.RuleType, err)
+	}
+	return cfg, nil
+}
+
+func nilTypedConfigError() error {
+	return &ValidationError{OnCallError: OnCallError{Message: "TypedConfig must not be a nil pointer"}}
+}
+
+func ruleConfigMap(cfg RuleConfig) (map[string]any, error) {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal rule config: %w", err)
+	}
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to marshal rule config: %w", err)
+	}
+	return m, nil
+}
+
+func (in *CreateRelayRuleInput) applyTypedConfig() error {
+	if in.TypedConfig == nil {
+		return nil
+	}
+	if nilRuleConfig(in.TypedConfig) {
+		return nilTypedConfigError()
+	}
+	if in.Config != nil {
+		return &ValidationError{OnCallError: OnCallError{Message: "set either Config or TypedConfig, not both"}}
+	}
+	ruleType := in.TypedConfig.RuleType()
+	if in.RuleType != "" && in.RuleType != ruleType {
+		return &ValidationError{OnCallError: OnCallError{Message: fmt.Sprintf("rule type %s does not match %s config", in.RuleType, ruleType)}}
+	}
+
+	config, err := ruleConfigMap(in.TypedConfig)
+	if err != nil {
+		return err
+	}
+	in.RuleType = ruleType
+	in.Config = config
+	return nil
+}
+
+func (in *UpdateRelayRuleInput) applyTypedConfig() error {
+	if in.TypedConfig == nil {
+		return nil
+	}
+	if nilRuleConfig(in.TypedConfig) {
+		return nilTypedConfigError()
+	}
+	if in.Config != nil {
+		return &ValidationError{OnCallError: OnCallError{Message: "set either Config or TypedConfig, not both"}}
+	}
+	ruleType := in.TypedConfig.RuleType()
+	if in.RuleType != nil && *in.RuleType != ruleType {
+		return &ValidationError{OnCallError: OnCallError{Message: fmt.Sprintf("rule type %s does not match %s config", *in.RuleType, ruleType)}}
+	}
+
+	config, err := ruleConfigMap(in.TypedConfig)
+	if err != nil {
+		return err
+	}
+	in.RuleType = &ruleType
+	in.Config = config
+	return nil
+}
@@ -0,0 +1,88 @@
+package oncall
+
+import "testing"
+
+func TestRelayRuleTypedConfig(t *testing.T) {
+	rule := RelayRule{
+		ID:       "rule1",
+		RuleType: RuleTypeWait,
+		Config:   map[string]any{"duration": float64(300)},
+	}
+
+	cfg, err := rule.TypedConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wait, ok := cfg.(WaitConfig)
+	if !ok {
+		t.Fatalf("expected WaitConfig, got %T", cfg)
+	}
+	if wait.Duration != 300 {
+		t.Fatalf("unexpected duration: %v", wait.Duration)
+	}
+
+	if _, err := RuleConfigAs[EscalateConfig](rule); err == nil {
+		t.Fatal("expected error decoding wait rule as EscalateConfig")
+	}
+
+	rule.RuleType = "unknown"
+	if _, err := rule.TypedConfig(); err == nil {
+		t.Fatal("expected error for unknown rule type")
+	}
+}
+
+func TestCreateRelayRuleInputTypedConfig(t *testing.T) {
+	t.Run("sets rule type and config", func(t *testing.T) {
+		input := CreateRelayRuleInput{
+			Name:        "page",
+			TypedConfig: ScheduleNotifyConfig{ScheduleID: "sched1"},
+		}
+		if err := input.applyTypedConfig(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if input.RuleType != RuleTypeScheduleNotify {
+			t.Fatalf("unexpected rule type: %v", input.RuleType)
+		}
+		if input.Config["scheduleId"] != "sched1" {
+			t.Fatalf("unexpected config: %v", input.Config)
+		}
+	})
+
+	t.Run("rejects mismatched rule type", func(t *testing.T) {
+		input := CreateRelayRuleInput{
+			RuleType:    RuleTypeWebhook,
+			TypedConfig: WaitConfig{Duration: 60},
+		}
+		if _, ok := input.applyTypedConfig().(*ValidationError); !ok {
+			t.Fatal("expected ValidationError for mismatched rule type")
+		}
+	})
+}
+
+func TestNilPointerRuleConfig(t *testing.T) {
+	rule := RelayRule{ID: "rule1", RuleType: RuleTypeWait, Config: map[string]any{"duration": float64(300)}}
+	if _, err := RuleConfigAs[*WaitConfig](rule); err == nil {
+		t.Fatal("expected an error for a pointer type parameter")
+	}
+
+	var cfg *WaitConfig
+	create := CreateRelayRuleInput{Name: "wait", TypedConfig: cfg}
+	if _, ok := create.Validate().(*ValidationError); !ok {
+		t.Fatalf("expected *ValidationError from Validate, got %v", create.Validate())
+	}
+	if _, ok := create.applyTypedConfig().(*ValidationError); !ok {
+		t.Fatal("expected *ValidationError from applyTypedConfig")
+	}
+	update := UpdateRelayRuleInput{TypedConfig: cfg}
+	if _, ok := update.Validate().(*ValidationError); !ok {
+		t.Fatalf("expected *ValidationError from Validate, got %v", update.Validate())
+	}
+	if _, ok := update.applyTypedConfig().(*ValidationError); !ok {
+		t.Fatal("expected *ValidationError from applyTypedConfig")
+	}
+
+	update.TypedConfig = &WaitConfig{Duration: 60}
+	if err := update.applyTypedConfig(); err != nil || update.Config["duration"] != float64(60) {
+		t.Fatalf("expected a non-nil pointer config to be applied, got %v, %v", update.Config, err)
+	}
+}
@@ -7,11 +7,15 @@ import (
 )
 
 type ScheduleResource struct {
-	http *httpClient
+	http      *httpClient
+	Overrides *ScheduleOverridesResource
 }
 
 func newScheduleResource(http *httpClient) *ScheduleResource {
-	return &ScheduleResource{http: http}
+	return &ScheduleResource{
+		http:      http,
+		Overrides: newScheduleOverridesResource(http),
+	}
 }
 
 func (s *ScheduleResource) Create(ctx context.Context, input CreateScheduleInput) (*Schedule, error) {
@@ -34,6 +38,50 @@ func (s *ScheduleResource) List(ctx context.Context) ([]Schedule, error) {
 	return result.Schedules, nil
 }
 
+func (s *ScheduleResource) Get(ctx context.Context, scheduleID string) (*Schedule, error) {
+	var result struct {
+		Schedule Schedule `json:"schedule"`
+	}
+	path := fmt.Sprintf("/schedule/%s", scheduleID)
+	if err := s.http.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+	return &result.Schedule, nil
+}
+
+func (s *ScheduleResource) Update(ctx context.Context, scheduleID string, input UpdateScheduleInput) (*Schedule, error) {
+	var result struct {
+		Schedule Schedule `json:"schedule"`
+	}
+	path := fmt.Sprintf("/schedule/%s", scheduleID)
+	if err := s.http.put(ctx, path, input, &result); err != nil {
+		return nil, err
+	}
+	return &result.Schedule, nil
+}
+
+func (s *ScheduleResource) Delete(ctx context.Context, scheduleID string) error {
+	var result struct {
+		Success bool `json:"success"`
+	}
+	path := fmt.Sprintf("/schedule/%s", scheduleID)
+	if err := s.http.delete(ctx, path, &result); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *ScheduleResource) ListMembers(ctx context.Context, scheduleID string) ([]ScheduleMember, error) {
+	var result struct {
+		Members []ScheduleMember `json:"members"`
+	}
+	path := fmt.Sprintf("/schedule/%s/members", scheduleID)
+	if err := s.http.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+	return result.Members, nil
+}
+
 func (s *ScheduleResource) AddMember(ctx context.Context, scheduleID string, input AddScheduleMemberInput) (*ScheduleMember, error) {
 	var result struct {
 		Member ScheduleMember `json:"member"`
@@ -45,6 +93,28 @@ func (s *ScheduleResource) AddMember(ctx context.Context, scheduleID string, inp
 	return &result.Member, nil
 }
 
+func (s *ScheduleResource) RemoveMember(ctx context.Context, scheduleID, userID string) error {
+	var result struct {
+		Success bool `json:"success"`
+	}
+	path := fmt.Sprintf("/schedule/%s/members/%s", scheduleID, userID)
+	if err := s.http.delete(ctx, path, &result); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *ScheduleResource) ReorderMembers(ctx context.Context, scheduleID string, input ReorderScheduleMembersInput) ([]ScheduleMember, error) {
+	var result struct {
+		Members []ScheduleMember `json:"members"`
+	}
+	path := fmt.Sprintf("/schedule/%s/members/reorder", scheduleID)
+	if err := s.http.put(ctx, path, input, &result); err != nil {
+		return nil, err
+	}
+	return result.Members, nil
+}
+
 func (s *ScheduleResource) GetAssignments(ctx context.Context, scheduleID string, params *GetAssignmentsParams) ([]ScheduleAssignment, error) {
 	path := fmt.Sprintf("/schedule/%s/assignments", scheduleID)
 
@@ -84,6 +154,152 @@ func (s *ScheduleResource) GetOnCall(ctx context.Context, scheduleID string) (*O
 	return &result.OnCall, nil
 }
 
+// UpsertByExternalKey creates the schedule identified by input.ExternalKey, or
+// updates its name and rotation if it already exists. Schedules cannot move
+// between relays, so a RelayID that differs from the existing schedule's is
+// rejected.
+func (s *ScheduleResource) UpsertByExternalKey(ctx context.Context, input CreateScheduleInput) (*UpsertResult[Schedule], error) {
+	if err := requireExternalKey(input.ExternalKey); err != nil {
+		return nil, err
+	}
+
+	schedules, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var existing *Schedule
+	for i := range schedules {
+		if schedules[i].ExternalKey != nil && *schedules[i].ExternalKey == *input.ExternalKey {
+			existing = &schedules[i]
+			break
+		}
+	}
+
+	if existing == nil {
+		schedule, err := s.Create(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		return &UpsertResult[Schedule]{Data: *schedule, Action: UpsertCreated}, nil
+	}
+
+	if existing.RelayID != input.RelayID {
+		return nil, &ValidationError{OnCallError: OnCallError{
+			Message: fmt.Sprintf("schedule %q belongs to relay %s and cannot be moved to relay %s", *input.ExternalKey, existing.RelayID, input.RelayID),
+		}}
+	}
+
+	var update UpdateScheduleInput
+	changed := false
+	if existing.Name != input.Name {
+		update.Name = &input.Name
+		changed = true
+	}
+	if existing.Type != input.Type {
+		update.Type = &input.Type
+		changed = true
+	}
+	if existing.StartDay != input.StartDay {
+		update.StartDay = &input.StartDay
+		changed = true
+	}
+	if existing.StartTime != input.StartTime {
+		update.StartTime = &input.StartTime
+		changed = true
+	}
+	if !changed {
+		return &UpsertResult[Schedule]{Data: *existing, Action: UpsertUnchanged}, nil
+	}
+
+	schedule, err := s.Update(ctx, existing.ID, update)
+	if err != nil {
+		return nil, err
+	}
+	return &UpsertResult[Schedule]{Data: *schedule, Action: UpsertUpdated}, nil
+}
+
+func (s *ScheduleResource) CreateSafe(ctx context.Context, input CreateScheduleInput) Result[Schedule] {
+	schedule, err := s.Create(ctx, input)
+	if err != nil {
+		return Result[Schedule]{Error: err}
+	}
+	return Result[Schedule]{Data: schedule}
+}
+
+func (s *ScheduleResource) ListSafe(ctx context.Context) Result[[]Schedule] {
+	schedules, err := s.List(ctx)
+	if err != nil {
+		return Result[[]Schedule]{Error: err}
+	}
+	return Result[[]Schedule]{Data: &schedules}
+}
+
+func (s *ScheduleResource) GetSafe(ctx context.Context, scheduleID string) Result[Schedule] {
+	schedule, err := s.Get(ctx, scheduleID)
+	if err != nil {
+		return Result[Schedule]{Error: err}
+	}
+	return Result[Schedule]{Data: schedule}
+}
+
+func (s *ScheduleResource) UpdateSafe(ctx context.Context, scheduleID string, input UpdateScheduleInput) Result[Schedule] {
+	schedule, err := s.Update(ctx, scheduleID, input)
+	if err != nil {
+		return Result[Schedule]{Error: err}
+	}
+	return Result[Schedule]{Data: schedule}
+}
+
+func (s *ScheduleResource) DeleteSafe(ctx context.Context, scheduleID string) Result[bool] {
+	err := s.Delete(ctx, scheduleID)
+	if err != nil {
+		return Result[bool]{Error: err}
+	}
+	success := true
+	return Result[bool]{Data: &success}
+}
+
+func (s *ScheduleResource) ListMembersSafe(ctx context.Context, scheduleID string) Result[[]ScheduleMember] {
+	members, err := s.ListMembers(ctx, scheduleID)
+	if err != nil {
+		return Result[[]ScheduleMember]{Error: err}
+	}
+	return Result[[]ScheduleMember]{Data: &members}
+}
+
+func (s *ScheduleResource) AddMemberSafe(ctx context.Context, scheduleID string, input AddScheduleMemberInput) Result[ScheduleMember] {
+	member, err := s.AddMember(ctx, scheduleID, input)
+	if err != nil {
+		return Result[ScheduleMember]{Error: err}
+	}
+	return Result[ScheduleMember]{Data: member}
+}
+
+func (s *ScheduleResource) RemoveMemberSafe(ctx context.Context, scheduleID, userID string) Result[bool] {
+	err := s.RemoveMember(ctx, scheduleID, userID)
+	if err != nil {
+		return Result[bool]{Error: err}
+	}
+	success := true
+	return Result[bool]{Data: &success}
+}
+
+func (s *ScheduleResource) ReorderMembersSafe(ctx context.Context, scheduleID string, input ReorderScheduleMembersInput) Result[[]ScheduleMember] {
+	members, err := s.ReorderMembers(ctx, scheduleID, input)
+	if err != nil {
+		return Result[[]ScheduleMember]{Error: err}
+	}
+	return Result[[]ScheduleMember]{Data: &members}
+}
+
+func (s *ScheduleResource) GetAssignmentsSafe(ctx context.Context, scheduleID string, params *GetAssignmentsParams) Result[[]ScheduleAssignment] {
+	assignments, err := s.GetAssignments(ctx, scheduleID, params)
+	if err != nil {
+		return Result[[]ScheduleAssignment]{Error: err}
+	}
+	return Result[[]ScheduleAssignment]{Data: &assignments}
+}
+
 func (s *ScheduleResource) GetOnCallSafe(ctx context.Context, scheduleID string) Result[OnCallUser] {
 	onCall, err := s.GetOnCall(ctx, scheduleID)
 	if err != nil {
@@ -91,3 +307,11 @@ func (s *ScheduleResource) GetOnCallSafe(ctx context.Context, scheduleID string)
 	}
 	return Result[OnCallUser]{Data: onCall}
 }
+
+func (s *ScheduleResource) UpsertByExternalKeySafe(ctx context.Context, input CreateScheduleInput) Result[UpsertResult[Schedule]] {
+	result, err := s.UpsertByExternalKey(ctx, input)
+	if err != nil {
+		return Result[UpsertResult[Schedule]]{Error: err}
+	}
+	return Result[UpsertResult[Schedule]]{Data: result}
+}
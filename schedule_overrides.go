@@ -0,0 +1,149 @@
+package oncall
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+type ScheduleOverridesResource struct {
+	http *httpClient
+}
+
+func newScheduleOverridesResource(http *httpClient) *ScheduleOverridesResource {
+	return &ScheduleOverridesResource{http: http}
+}
+
+func (o *ScheduleOverridesResource) List(ctx context.Context, scheduleID string) ([]ScheduleOverride, error) {
+	var result struct {
+		Overrides []ScheduleOverride `json:"overrides"`
+	}
+	path := fmt.Sprintf("/schedule/%s/overrides", scheduleID)
+	if err := o.http.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+	return result.Overrides, nil
+}
+
+func (o *ScheduleOverridesResource) Create(ctx context.Context, scheduleID string, input CreateScheduleOverrideInput) (*ScheduleOverride, error) {
+	if !input.EndsAt.After(input.StartsAt) {
+		return nil, &ValidationError{OnCallError: OnCallError{Message: "override endsAt must be after startsAt"}}
+	}
+
+	var result struct {
+		Override ScheduleOverride `json:"override"`
+	}
+	path := fmt.Sprintf("/schedule/%s/overrides", scheduleID)
+	if err := o.http.post(ctx, path, input, &result); err != nil {
+		return nil, err
+	}
+	return &result.Override, nil
+}
+
+func (o *ScheduleOverridesResource) Delete(ctx context.Context, scheduleID, overrideID string) error {
+	var result struct {
+		Success bool `json:"success"`
+	}
+	path := fmt.Sprintf("/schedule/%s/overrides/%s", scheduleID, overrideID)
+	if err := o.http.delete(ctx, path, &result); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Swap exchanges two assignment windows by covering each with an override for
+// the other user. If the second override cannot be created the first one is
+// removed again so the schedule is left unchanged.
+func (o *ScheduleOverridesResource) Swap(ctx context.Context, scheduleID string, a, b ScheduleAssignment) ([]ScheduleOverride, error) {
+	if a.UserID == b.UserID {
+		return nil, &ValidationError{OnCallError: OnCallError{Message: "cannot swap assignments belonging to the same user"}}
+	}
+
+	aStart, aEnd, err := assignmentWindow(a)
+	if err != nil {
+		return nil, err
+	}
+	bStart, bEnd, err := assignmentWindow(b)
+	if err != nil {
+		return nil, err
+	}
+
+	first, err := o.Create(ctx, scheduleID, CreateScheduleOverrideInput{
+		UserID:   b.UserID,
+		StartsAt: aStart,
+		EndsAt:   aEnd,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	second, err := o.Create(ctx, scheduleID, CreateScheduleOverrideInput{
+		UserID:   a.UserID,
+		StartsAt: bStart,
+		EndsAt:   bEnd,
+	})
+	if err != nil {
+		if rollbackErr := o.Delete(ctx, scheduleID, first.ID); rollbackErr != nil {
+			return nil, fmt.Errorf("%w (rollback of override %s failed: %v)", err, first.ID, rollbackErr)
+		}
+		return nil, err
+	}
+
+	return []ScheduleOverride{*first, *second}, nil
+}
+
+func (o *ScheduleOverridesResource) ListSafe(ctx context.Context, scheduleID string) Result[[]ScheduleOverride] {
+	overrides, err := o.List(ctx, scheduleID)
+	if err != nil {
+		return Result[[]ScheduleOverride]{Error: err}
+	}
+	return Result[[]ScheduleOverride]{Data: &overrides}
+}
+
+func (o *ScheduleOverridesResource) CreateSafe(ctx context.Context, scheduleID string, input CreateScheduleOverrideInput) Result[ScheduleOverride] {
+	override, err := o.Create(ctx, scheduleID, input)
+	if err != nil {
+		return Result[ScheduleOverride]{Error: err}
+	}
+	return Result[ScheduleOverride]{Data: override}
+}
+
+func (o *ScheduleOverridesResource) DeleteSafe(ctx context.Context, scheduleID, overrideID string) Result[bool] {
+	err := o.Delete(ctx, scheduleID, overrideID)
+	if err != nil {
+		return Result[bool]{Error: err}
+	}
+	success := true
+	return Result[bool]{Data: &success}
+}
+
+func (o *ScheduleOverridesResource) SwapSafe(ctx context.Context, scheduleID string, a, b ScheduleAssignment) Result[[]ScheduleOverride] {
+	overrides, err := o.Swap(ctx, scheduleID, a, b)
+	if err != nil {
+		return Result[[]ScheduleOverride]{Error: err}
+	}
+	return Result[[]ScheduleOverride]{Data: &overrides}
+}
+
+func assignmentWindow(a ScheduleAssignment) (time.Time, time.Time, error) {
+	start, err := parseAssignmentTime(a.StartDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	end, err := parseAssignmentTime(a.EndDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return start, end, nil
+}
+
+func parseAssignmentTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid assignment date %q", value)
+	}
+	return t, nil
+}
@@ -0,0 +1,46 @@
+package oncall
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestSwapRollsBackFirstOverride(t *testing.T) {
+	var creates int
+	var deleted []string
+	client := newTestClientWithConfig(t, Config{BackoffMs: 1}, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/schedule/sched1/overrides":
+			var input CreateScheduleOverrideInput
+			json.NewDecoder(r.Body).Decode(&input)
+			if input.UserID == "alice" {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(`{"error":"internal"}`))
+				return
+			}
+			creates++
+			json.NewEncoder(w).Encode(map[string]any{"override": ScheduleOverride{ID: "ovr1", UserID: input.UserID}})
+		case r.Method == http.MethodDelete:
+			deleted = append(deleted, r.URL.Path)
+			w.Write([]byte(`{"success":true}`))
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"unexpected request"}`))
+		}
+	})
+
+	a := ScheduleAssignment{UserID: "alice", StartDate: "2026-01-05T09:00:00Z", EndDate: "2026-01-12T09:00:00Z"}
+	b := ScheduleAssignment{UserID: "bob", StartDate: "2026-01-12T09:00:00Z", EndDate: "2026-01-19T09:00:00Z"}
+	if _, err := client.Schedule.Overrides.Swap(context.Background(), "sched1", a, b); err == nil {
+		t.Fatal("expected error when the second override fails")
+	}
+	if creates != 1 {
+		t.Fatalf("expected the first override to be created once, got %d", creates)
+	}
+	if len(deleted) != 1 || deleted[0] != "/schedule/sched1/overrides/ovr1" {
+		t.Fatalf("expected the first override to be deleted, got %v", deleted)
+	}
+}
@@ -0,0 +1,92 @@
+package oncall
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestUpsertScheduleByExternalKey(t *testing.T) {
+	var schedules []Schedule
+	var updates []map[string]any
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/schedule":
+			json.NewEncoder(w).Encode(map[string]any{"schedules": schedules})
+		case r.Method == http.MethodPost && r.URL.Path == "/schedule":
+			var input CreateScheduleInput
+			json.NewDecoder(r.Body).Decode(&input)
+			schedule := Schedule{
+				ID:          "sched1",
+				RelayID:     input.RelayID,
+				Name:        input.Name,
+				Type:        input.Type,
+				StartDay:    input.StartDay,
+				StartTime:   input.StartTime,
+				ExternalKey: input.ExternalKey,
+			}
+			schedules = append(schedules, schedule)
+			json.NewEncoder(w).Encode(map[string]any{"schedule": schedule})
+		case r.Method == http.MethodPut && r.URL.Path == "/schedule/sched1":
+			body, _ := io.ReadAll(r.Body)
+			var input UpdateScheduleInput
+			var raw map[string]any
+			json.Unmarshal(body, &input)
+			json.Unmarshal(body, &raw)
+			updates = append(updates, raw)
+			if input.StartTime != nil {
+				schedules[0].StartTime = *input.StartTime
+			}
+			json.NewEncoder(w).Encode(map[string]any{"schedule": schedules[0]})
+		default:
+			t.Fatalf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	})
+	ctx := context.Background()
+
+	key := "primary"
+	input := CreateScheduleInput{
+		Name:        "Primary",
+		RelayID:     "relay1",
+		Type:        ScheduleTypeWeekly,
+		StartDay:    Monday,
+		StartTime:   TimeOfDay{Hour: 9},
+		ExternalKey: &key,
+	}
+	for _, want := range []UpsertAction{UpsertCreated, UpsertUnchanged} {
+		result, err := client.Schedule.UpsertByExternalKey(ctx, input)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Action != want || result.Data.ID != "sched1" {
+			t.Fatalf("expected %s of sched1, got %+v", want, result)
+		}
+	}
+	if len(schedules) != 1 || len(updates) != 0 {
+		t.Fatalf("expected one schedule and no updates, got %d and %d", len(schedules), len(updates))
+	}
+
+	input.StartTime = TimeOfDay{Hour: 10, Minute: 30}
+	result, err := client.Schedule.UpsertByExternalKey(ctx, input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Action != UpsertUpdated || result.Data.StartTime != (TimeOfDay{Hour: 10, Minute: 30}) {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if len(updates) != 1 || len(updates[0]) != 1 || updates[0]["startTime"] != "10:30" {
+		t.Fatalf("expected an update of the start time only, got %v", updates)
+	}
+
+	input.RelayID = "relay2"
+	var verr *ValidationError
+	if _, err := client.Schedule.UpsertByExternalKey(ctx, input); !errors.As(err, &verr) {
+		t.Fatalf("expected ValidationError when moving to another relay, got %v", err)
+	}
+	if len(updates) != 1 {
+		t.Fatalf("expected no update when moving to another relay, got %v", updates)
+	}
+}
@@ -0,0 +1,271 @@
+package oncall
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+const defaultSimulationMaxSteps = 1000
+
+type Clock interface {
+	Now() time.Time
+}
+
+type ClockFunc func() time.Time
+
+func (f ClockFunc) Now() time.Time { return f() }
+
+// FixedClock returns a Clock that always reports t.
+func FixedClock(t time.Time) Clock {
+	return ClockFunc(func() time.Time { return t })
+}
+
+// Acknowledger decides whether the alert has been acknowledged by the time
+// the simulation reaches the next rule. steps holds the timeline so far.
+type Acknowledger func(elapsed time.Duration, steps []SimulationStep) bool
+
+// AcknowledgeAfter acknowledges the alert once d has elapsed.
+func AcknowledgeAfter(d time.Duration) Acknowledger {
+	return func(elapsed time.Duration, _ []SimulationStep) bool { return elapsed >= d }
+}
+
+// AcknowledgeWhenNotified acknowledges the alert as soon as userID has been
+// paged.
+func AcknowledgeWhenNotified(userID string) Acknowledger {
+	return func(_ time.Duration, steps []SimulationStep) bool {
+		return slices.ContainsFunc(steps, func(s SimulationStep) bool {
+			return s.Kind == StepNotify && s.UserID == userID
+		})
+	}
+}
+
+type SimulationSchedule struct {
+	Schedule Schedule
+	Members  []ScheduleMember
+}
+
+type Simulation struct {
+	Rules     []RelayRule
+	Schedules []SimulationSchedule
+	Alert     Alert
+
+	// Clock provides the time the alert is triggered. Defaults to the
+	// system clock.
+	Clock Clock
+	// Acknowledged is consulted before every rule. Defaults to never.
+	Acknowledged Acknowledger
+	// Branch decides conditional rules. Defaults to ConditionalConfig.Evaluate
+	// at the simulated time, taking the false branch for conditions it cannot
+	// evaluate.
+	Branch func(cfg ConditionalConfig, alert Alert, elapsed time.Duration) bool
+	// OnCall overrides how the on-call user of a schedule is found. Defaults
+	// to rotating through Schedules.
+	OnCall func(scheduleID string, at time.Time) (string, error)
+	// MaxSteps stops simulations of relays that loop forever. Defaults to 1000.
+	MaxSteps int
+}
+
+type SimulationStepKind string
+
+const (
+	StepNotify       SimulationStepKind = "notify"
+	StepWebhook      SimulationStepKind = "webhook"
+	StepAgent        SimulationStepKind = "agent"
+	StepExternalAPI  SimulationStepKind = "external_api"
+	StepWait         SimulationStepKind = "wait"
+	StepConditional  SimulationStepKind = "conditional"
+	StepEscalate     SimulationStepKind = "escalate"
+	StepExhausted    SimulationStepKind = "exhausted"
+	StepAcknowledged SimulationStepKind = "acknowledged"
+	StepCompleted    SimulationStepKind = "completed"
+)
+
+type SimulationStep struct {
+	Kind     SimulationStepKind
+	At       time.Time
+	Elapsed  time.Duration
+	RuleID   string
+	RuleName string
+
+	ScheduleID string
+	UserID     string
+	Method     *NotificationMethod
+	Endpoint   string
+	Duration   time.Duration
+	Branch     *bool
+	Attempt    int
+}
+
+func (s SimulationStep) String() string {
+	prefix := fmt.Sprintf("t+%s", s.Elapsed)
+	switch s.Kind {
+	case StepNotify:
+		via := ""
+		if s.Method != nil {
+			via = " via " + string(*s.Method)
+		}
+		return fmt.Sprintf("%s notify schedule %s (user %s)%s", prefix, s.ScheduleID, s.UserID, via)
+	case StepWebhook, StepAgent, StepExternalAPI:
+		return fmt.Sprintf("%s %s %s", prefix, strings.ReplaceAll(string(s.Kind), "_", " "), s.Endpoint)
+	case StepWait:
+		return fmt.Sprintf("%s wait %s", prefix, s.Duration)
+	case StepConditional:
+		return fmt.Sprintf("%s conditional %q took %t branch", prefix, s.RuleName, *s.Branch)
+	case StepEscalate:
+		return fmt.Sprintf("%s escalate attempt %d, restarting after %s", prefix, s.Attempt, s.Duration)
+	case StepExhausted:
+		return fmt.Sprintf("%s escalation exhausted after %d attempts", prefix, s.Attempt)
+	default:
+		return fmt.Sprintf("%s %s", prefix, s.Kind)
+	}
+}
+
+type SimulationResult struct {
+	Steps        []SimulationStep
+	Acknowledged bool
+}
+
+// Simulate walks a relay's rules for a hypothetical alert and records who is
+// paged and when, following the execution model described on AnalyzeRelay.
+// No API calls are made.
+func Simulate(sim Simulation) (*SimulationResult, error) {
+	g, issues := newRelayGraph(sim.Rules)
+	if len(issues) > 0 {
+		return nil, errors.New(issues[0].Message)
+	}
+
+	clock := sim.Clock
+	if clock == nil {
+		clock = ClockFunc(time.Now)
+	}
+	maxSteps := sim.MaxSteps
+	if maxSteps <= 0 {
+		maxSteps = defaultSimulationMaxSteps
+	}
+	onCall := sim.OnCall
+	if onCall == nil {
+		onCall = scheduleRotations(sim.Schedules)
+	}
+
+	start := clock.Now()
+	now := start
+	result := &SimulationResult{}
+	attempts := make(map[string]int)
+
+	record := func(step SimulationStep) {
+		step.At = now
+		step.Elapsed = now.Sub(start)
+		result.Steps = append(result.Steps, step)
+	}
+
+	current := g.first()
+	for executed := 0; current != ""; executed++ {
+		if executed >= maxSteps {
+			return result, fmt.Errorf("simulation did not finish within %d steps", maxSteps)
+		}
+		if sim.Acknowledged != nil && sim.Acknowledged(now.Sub(start), result.Steps) {
+			result.Acknowledged = true
+			record(SimulationStep{Kind: StepAcknowledged})
+			return result, nil
+		}
+
+		rule := g.all[current]
+		step := SimulationStep{RuleID: rule.ID, RuleName: rule.Name}
+		next := g.next(current)
+
+		switch cfg := g.configs[current].(type) {
+		case ScheduleNotifyConfig:
+			userID, err := onCall(cfg.ScheduleID, now)
+			if err != nil {
+				return result, fmt.Errorf("rule %q: %w", rule.Name, err)
+			}
+			step.Kind = StepNotify
+			step.ScheduleID = cfg.ScheduleID
+			step.UserID = userID
+			step.Method = cfg.NotificationMethod
+			record(step)
+		case WebhookConfig:
+			step.Kind = StepWebhook
+			step.Endpoint = cfg.Endpoint
+			record(step)
+		case AgentConfig:
+			step.Kind = StepAgent
+			step.Endpoint = string(cfg.AgentType)
+			record(step)
+		case ExternalApiConfig:
+			step.Kind = StepExternalAPI
+			step.Endpoint = cfg.APIType
+			record(step)
+		case WaitConfig:
+			step.Kind = StepWait
+			step.Duration = cfg.Duration.Duration()
+			record(step)
+			now = now.Add(step.Duration)
+		case ConditionalConfig:
+			var taken bool
+			if sim.Branch != nil {
+				taken = sim.Branch(cfg, sim.Alert, now.Sub(start))
+			} else {
+				taken, _ = cfg.Evaluate(sim.Alert, now)
+			}
+			step.Kind = StepConditional
+			step.Branch = &taken
+			record(step)
+			target := cfg.FalseRuleID
+			if taken {
+				target = cfg.TrueRuleID
+			}
+			if target != nil {
+				next = *target
+			}
+		case EscalateConfig:
+			attempts[current]++
+			step.Attempt = attempts[current]
+			if step.Attempt > cfg.MaxAttempts {
+				step.Kind = StepExhausted
+				step.Attempt = cfg.MaxAttempts
+				record(step)
+				return result, nil
+			}
+			step.Kind = StepEscalate
+			step.Duration = cfg.EscalateAfter.Duration()
+			record(step)
+			now = now.Add(step.Duration)
+			next = g.first()
+			if cfg.ResetToRuleID != nil {
+				next = *cfg.ResetToRuleID
+			}
+		}
+
+		if next != "" {
+			if _, ok := g.index[next]; !ok {
+				return result, fmt.Errorf("rule %q continues to rule %s, which is missing or disabled", rule.Name, next)
+			}
+		}
+		current = next
+	}
+
+	record(SimulationStep{Kind: StepCompleted})
+	return result, nil
+}
+
+func scheduleRotations(schedules []SimulationSchedule) func(string, time.Time) (string, error) {
+	byID := make(map[string]SimulationSchedule, len(schedules))
+	for _, s := range schedules {
+		byID[s.Schedule.ID] = s
+	}
+	return func(scheduleID string, at time.Time) (string, error) {
+		s, ok := byID[scheduleID]
+		if !ok {
+			return "", fmt.Errorf("schedule %s is not part of the simulation", scheduleID)
+		}
+		assignment, err := AssignmentAt(s.Schedule, s.Members, at)
+		if err != nil {
+			return "", err
+		}
+		return assignment.UserID, nil
+	}
+}
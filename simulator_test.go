@@ -0,0 +1,115 @@
+package oncall
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSimulate(t *testing.T) {
+	sms := NotificationSMS
+	schedule := Schedule{
+		ID:        "s1",
+		Type:      ScheduleTypeDaily,
+		StartTime: TimeOfDay{Hour: 9},
+		CreatedAt: time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC),
+	}
+	members := []ScheduleMember{
+		{ScheduleID: "s1", UserID: "bob", Order: 1},
+		{ScheduleID: "s1", UserID: "alice", Order: 0},
+	}
+	rules := []RelayRule{
+		testRule("notify", 1, RuleTypeScheduleNotify, map[string]any{"scheduleId": "s1", "notificationMethod": string(sms)}),
+		testRule("wait", 2, RuleTypeWait, map[string]any{"duration": 300}),
+		testRule("escalate", 3, RuleTypeEscalate, map[string]any{"maxAttempts": 2, "escalateAfter": 60}),
+	}
+	start := time.Date(2026, 1, 6, 8, 58, 0, 0, time.UTC)
+
+	t.Run("escalates until exhausted", func(t *testing.T) {
+		result, err := Simulate(Simulation{
+			Rules:     rules,
+			Schedules: []SimulationSchedule{{Schedule: schedule, Members: members}},
+			Clock:     FixedClock(start),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var got []string
+		for _, step := range result.Steps {
+			got = append(got, step.String())
+		}
+		want := []string{
+			"t+0s notify schedule s1 (user alice) via sms",
+			"t+0s wait 5m0s",
+			"t+5m0s escalate attempt 1, restarting after 1m0s",
+			"t+6m0s notify schedule s1 (user bob) via sms",
+			"t+6m0s wait 5m0s",
+			"t+11m0s escalate attempt 2, restarting after 1m0s",
+			"t+12m0s notify schedule s1 (user bob) via sms",
+			"t+12m0s wait 5m0s",
+			"t+17m0s escalation exhausted after 2 attempts",
+		}
+		if strings.Join(got, "\n") != strings.Join(want, "\n") {
+			t.Fatalf("unexpected timeline:\n%s", strings.Join(got, "\n"))
+		}
+		if result.Acknowledged {
+			t.Fatal("expected alert to stay unacknowledged")
+		}
+	})
+
+	t.Run("stops when acknowledged", func(t *testing.T) {
+		result, err := Simulate(Simulation{
+			Rules:        rules,
+			Schedules:    []SimulationSchedule{{Schedule: schedule, Members: members}},
+			Clock:        FixedClock(start),
+			Acknowledged: AcknowledgeWhenNotified("bob"),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		last := result.Steps[len(result.Steps)-1]
+		if !result.Acknowledged || last.Kind != StepAcknowledged || last.Elapsed != 6*time.Minute {
+			t.Fatalf("expected acknowledgement at t+6m, got %s", last)
+		}
+	})
+
+	t.Run("follows conditional branches", func(t *testing.T) {
+		result, err := Simulate(Simulation{
+			Rules: []RelayRule{
+				testRule("cond", 1, RuleTypeConditional, map[string]any{"condition": "severity", "trueRuleId": "hook"}),
+				testRule("wait", 2, RuleTypeWait, map[string]any{"duration": 60}),
+				testRule("hook", 3, RuleTypeWebhook, map[string]any{"endpoint": "https://example.com/hook"}),
+			},
+			Alert: Alert{Severity: SeverityCritical},
+			Clock: FixedClock(start),
+			Branch: func(cfg ConditionalConfig, alert Alert, _ time.Duration) bool {
+				return alert.Severity == SeverityCritical
+			},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		kinds := []SimulationStepKind{}
+		for _, step := range result.Steps {
+			kinds = append(kinds, step.Kind)
+		}
+		if len(kinds) != 3 || kinds[0] != StepConditional || kinds[1] != StepWebhook || kinds[2] != StepCompleted {
+			t.Fatalf("unexpected steps: %v", kinds)
+		}
+	})
+
+	t.Run("guards against endless loops", func(t *testing.T) {
+		_, err := Simulate(Simulation{
+			Rules: []RelayRule{
+				testRule("wait", 1, RuleTypeWait, map[string]any{"duration": 60}),
+				testRule("cond", 2, RuleTypeConditional, map[string]any{"condition": "acknowledged", "falseRuleId": "wait"}),
+			},
+			Clock:    FixedClock(start),
+			MaxSteps: 50,
+		})
+		if err == nil {
+			t.Fatal("expected error for endless loop")
+		}
+	})
+}
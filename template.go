@@ -0,0 +1,206 @@
+package oncall
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// RenderedRequest is the HTTP request a webhook or external API rule sends.
+type RenderedRequest struct {
+	Method  HTTPMethod
+	URL     string
+	Headers map[string]string
+	Body    []byte
+}
+
+// NewHTTPRequest builds an *http.Request from the rendered request.
+func (r *RenderedRequest) NewHTTPRequest(ctx context.Context) (*http.Request, error) {
+	var body io.Reader
+	if r.Body != nil {
+		body = bytes.NewReader(r.Body)
+	}
+	req, err := http.NewRequestWithContext(ctx, string(r.Method), r.URL, body)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range r.Headers {
+		req.Header.Set(k, v)
+	}
+	return req, nil
+}
+
+var templateVariable = regexp.MustCompile(`\{\{\s*([^{}]*?)\s*\}\}`)
+
+// RenderRequest renders the request a WebhookConfig or ExternalApiConfig
+// would send for alert, without sending it. It is a client-side approximation:
+// the placeholder syntax, escaping and missing-field handling below are this
+// package's own and have not been checked against the server's renderer, so
+// the server may send something different.
+//
+// The endpoint, header values and payload strings may contain {{variable}}
+// placeholders. Variables name the alert's JSON fields under "alert", such
+// as {{alert.title}}, {{alert.severity}}, {{alert.assignedToUserId}} or
+// {{alert.metadata.region}}. A payload string that is a single placeholder is
+// replaced by the value itself, so numbers, booleans and objects keep their
+// JSON type; anywhere else values are formatted as text. Without a payload
+// the body is the alert as JSON.
+//
+// Placeholders that do not name a field of the alert are reported as a
+// *ValidationError with one field error per occurrence.
+func RenderRequest(cfg RuleConfig, alert Alert) (*RenderedRequest, error) {
+	var (
+		endpoint string
+		method   *HTTPMethod
+		headers  map[string]string
+		payload  map[string]any
+	)
+	switch c := cfg.(type) {
+	case WebhookConfig:
+		endpoint, method, headers, payload = c.Endpoint, c.Method, c.Headers, c.Payload
+	case ExternalApiConfig:
+		if c.Endpoint == nil {
+			return nil, newFieldValidationError([]FieldError{{Path: "endpoint", Message: "is required to render a request locally"}})
+		}
+		endpoint, method, headers, payload = *c.Endpoint, c.Method, c.Headers, c.Payload
+	default:
+		return nil, &ValidationError{OnCallError: OnCallError{Message: fmt.Sprintf("cannot render a request for a %s rule", cfg.RuleType())}}
+	}
+
+	data, err := templateData(alert)
+	if err != nil {
+		return nil, err
+	}
+	t := &templateRenderer{data: data}
+
+	req := &RenderedRequest{
+		Method:  MethodPOST,
+		URL:     t.text("endpoint", endpoint),
+		Headers: map[string]string{"Content-Type": "application/json"},
+	}
+	if method != nil {
+		req.Method = *method
+	}
+	for k, v := range headers {
+		if strings.EqualFold(k, "Content-Type") {
+			delete(req.Headers, "Content-Type")
+		}
+		req.Headers[k] = t.text("headers."+k, v)
+	}
+
+	var body any = alert
+	if payload != nil {
+		body = t.value("payload", payload)
+	}
+	if err := t.errs.err(); err != nil {
+		return nil, err
+	}
+	if req.Body, err = json.Marshal(body); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+type templateRenderer struct {
+	data map[string]any
+	errs fieldErrors
+}
+
+// value renders the placeholders in the strings of a payload value.
+func (t *templateRenderer) value(path string, v any) any {
+	switch v := v.(type) {
+	case string:
+		if m := templateVariable.FindStringSubmatch(v); m != nil && m[0] == v {
+			value, _ := t.lookup(path, m[1])
+			return value
+		}
+		return t.text(path, v)
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, item := range v {
+			out[k] = t.value(path+"."+k, item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = t.value(fmt.Sprintf("%s[%d]", path, i), item)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func (t *templateRenderer) text(path, s string) string {
+	return templateVariable.ReplaceAllStringFunc(s, func(match string) string {
+		value, ok := t.lookup(path, templateVariable.FindStringSubmatch(match)[1])
+		if !ok {
+			return match
+		}
+		return templateText(value)
+	})
+}
+
+func (t *templateRenderer) lookup(path, variable string) (any, bool) {
+	var current any = t.data
+	for _, part := range strings.Split(variable, ".") {
+		m, ok := current.(map[string]any)
+		if ok {
+			current, ok = m[part]
+		}
+		if !ok {
+			t.errs.add(path, "unknown variable %q", variable)
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func templateText(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		b, _ := json.Marshal(v)
+		return string(b)
+	}
+}
+
+// templateData exposes the alert under "alert" with every JSON field present,
+// including unset optional ones, so they render as empty rather than unknown.
+func templateData(alert Alert) (map[string]any, error) {
+	b, err := json.Marshal(alert)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]any)
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+
+	typ := reflect.TypeOf(alert)
+	for i := 0; i < typ.NumField(); i++ {
+		name, _, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ",")
+		if _, ok := fields[name]; !ok && name != "" && name != "-" {
+			fields[name] = nil
+		}
+	}
+	if fields["metadata"] == nil {
+		fields["metadata"] = map[string]any{}
+	}
+	return map[string]any{"alert": fields}, nil
+}
@@ -0,0 +1,72 @@
+package oncall
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestRenderRequest(t *testing.T) {
+	assignee := "user-1"
+	alert := Alert{
+		ID:               "alert-1",
+		Title:            "Disk full",
+		Severity:         SeverityHigh,
+		Metadata:         map[string]any{"region": "eu-west-1", "usage": 97.5, "host": map[string]any{"name": "db-1"}},
+		AssignedToUserID: &assignee,
+	}
+	put := MethodPUT
+	cfg := WebhookConfig{
+		Endpoint: "https://example.com/hooks/{{alert.metadata.region}}",
+		Method:   &put,
+		Headers:  map[string]string{"X-Severity": "{{ alert.severity }}"},
+		Payload: map[string]any{
+			"text":    "[{{alert.severity}}] {{alert.title}} on {{alert.metadata.host.name}}",
+			"usage":   "{{alert.metadata.usage}}",
+			"message": "{{alert.message}}",
+			"tags":    []any{"{{alert.assignedToUserId}}", "static"},
+		},
+	}
+
+	req, err := RenderRequest(cfg, alert)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if req.Method != MethodPUT || req.URL != "https://example.com/hooks/eu-west-1" {
+		t.Fatalf("unexpected request line: %s %s", req.Method, req.URL)
+	}
+	if req.Headers["X-Severity"] != "high" || req.Headers["Content-Type"] != "application/json" {
+		t.Fatalf("unexpected headers: %v", req.Headers)
+	}
+	var body map[string]any
+	if err := json.Unmarshal(req.Body, &body); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body["text"] != "[high] Disk full on db-1" || body["usage"] != 97.5 || body["message"] != nil {
+		t.Fatalf("unexpected body: %s", req.Body)
+	}
+	if tags := body["tags"].([]any); tags[0] != "user-1" {
+		t.Fatalf("unexpected tags: %v", tags)
+	}
+
+	cfg.Payload = map[string]any{"a": "{{alert.metadata.missing}}", "b": "{{alert.titel}}"}
+	_, err = RenderRequest(cfg, alert)
+	var verr *ValidationError
+	if !errors.As(err, &verr) || len(verr.Fields) != 2 {
+		t.Fatalf("expected two unknown variable errors, got %v", err)
+	}
+
+	cfg.Payload = nil
+	req, err = RenderRequest(cfg, alert)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var decoded Alert
+	if err := json.Unmarshal(req.Body, &decoded); err != nil || decoded.ID != "alert-1" {
+		t.Fatalf("expected the alert as body, got %s", req.Body)
+	}
+
+	if _, err := RenderRequest(WaitConfig{Duration: 60}, alert); err == nil {
+		t.Fatal("expected error for a wait rule")
+	}
+}
@@ -7,12 +7,31 @@ type Result[T any] struct {
 	Error error
 }
 
+type UpsertAction string
+
+const (
+	UpsertCreated   UpsertAction = "created"
+	UpsertUpdated   UpsertAction = "updated"
+	UpsertUnchanged UpsertAction = "unchanged"
+)
+
+// UpsertResult is returned by the UpsertByExternalKey methods.
+type UpsertResult[T any] struct {
+	Data   T
+	Action UpsertAction
+}
+
 type CreateRelayInput struct {
 	Name        string  `json:"name"`
 	Description *string `json:"description,omitempty"`
 	ExternalKey *string `json:"externalKey,omitempty"`
 }
 
+type UpdateRelayInput struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
 type Relay struct {
 	ID             string    `json:"id"`
 	OrganizationID string    `json:"organizationId"`
@@ -47,7 +66,7 @@ type CreateScheduleInput struct {
 	RelayID     string       `json:"relayId"`
 	Type        ScheduleType `json:"type"`
 	StartDay    DayOfWeek    `json:"startDay"`
-	StartTime   string       `json:"startTime"`
+	StartTime   TimeOfDay    `json:"startTime"`
 	ExternalKey *string      `json:"externalKey,omitempty"`
 }
 
@@ -58,13 +77,20 @@ type Schedule struct {
 	Name           string       `json:"name"`
 	Type           ScheduleType `json:"type"`
 	StartDay       DayOfWeek    `json:"startDay"`
-	StartTime      string       `json:"startTime"`
+	StartTime      TimeOfDay    `json:"startTime"`
 	ExternalKey    *string      `json:"externalKey,omitempty"`
 	CreatedAt      time.Time    `json:"createdAt"`
 	UpdatedAt      time.Time    `json:"updatedAt"`
 	DeletedAt      *time.Time   `json:"deletedAt,omitempty"`
 }
 
+type UpdateScheduleInput struct {
+	Name      *string       `json:"name,omitempty"`
+	Type      *ScheduleType `json:"type,omitempty"`
+	StartDay  *DayOfWeek    `json:"startDay,omitempty"`
+	StartTime *TimeOfDay    `json:"startTime,omitempty"`
+}
+
 type AddScheduleMemberInput struct {
 	UserID string `json:"userId"`
 }
@@ -72,9 +98,14 @@ type AddScheduleMemberInput struct {
 type ScheduleMember struct {
 	ScheduleID string     `json:"scheduleId"`
 	UserID     string     `json:"userId"`
+	Order      int        `json:"order"`
 	CreatedAt  *time.Time `json:"createdAt,omitempty"`
 }
 
+type ReorderScheduleMembersInput struct {
+	UserIDs []string `json:"userIds"`
+}
+
 type GetAssignmentsParams struct {
 	Type  *string `json:"type,omitempty"`
 	Count *int    `json:"count,omitempty"`
@@ -82,10 +113,29 @@ type GetAssignmentsParams struct {
 }
 
 type ScheduleAssignment struct {
-	UserID           string `json:"userId"`
-	StartDate        string `json:"startDate"`
-	EndDate          string `json:"endDate"`
-	AssignmentNumber int    `json:"assignmentNumber"`
+	UserID           string  `json:"userId"`
+	StartDate        string  `json:"startDate"`
+	EndDate          string  `json:"endDate"`
+	AssignmentNumber int     `json:"assignmentNumber"`
+	IsOverride       bool    `json:"isOverride"`
+	OverrideID       *string `json:"overrideId,omitempty"`
+}
+
+type CreateScheduleOverrideInput struct {
+	UserID   string    `json:"userId"`
+	StartsAt time.Time `json:"startsAt"`
+	EndsAt   time.Time `json:"endsAt"`
+}
+
+type ScheduleOverride struct {
+	ID             string    `json:"id"`
+	OrganizationID string    `json:"organizationId"`
+	ScheduleID     string    `json:"scheduleId"`
+	UserID         string    `json:"userId"`
+	StartsAt       time.Time `json:"startsAt"`
+	EndsAt         time.Time `json:"endsAt"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
 }
 
 type RelayRuleType string
@@ -103,8 +153,12 @@ const (
 type NotificationMethod string
 
 const (
-	NotificationEmail NotificationMethod = "email"
-	NotificationSMS   NotificationMethod = "sms"
+	NotificationEmail   NotificationMethod = "email"
+	NotificationSMS     NotificationMethod = "sms"
+	NotificationVoice   NotificationMethod = "voice"
+	NotificationPush    NotificationMethod = "push"
+	NotificationSlack   NotificationMethod = "slack"
+	NotificationWebhook NotificationMethod = "webhook"
 )
 
 type ScheduleNotifyConfig struct {
@@ -127,7 +181,7 @@ type WebhookConfig struct {
 	Method   *HTTPMethod       `json:"method,omitempty"`
 	Headers  map[string]string `json:"headers,omitempty"`
 	Payload  map[string]any    `json:"payload,omitempty"`
-	Timeout  *int              `json:"timeout,omitempty"`
+	Timeout  *Millis           `json:"timeout,omitempty"`
 }
 
 type AgentType string
@@ -142,23 +196,23 @@ type AgentConfig struct {
 	IntegrationID   *string        `json:"integrationId,omitempty"`
 	Endpoint        *string        `json:"endpoint,omitempty"`
 	Config          map[string]any `json:"config,omitempty"`
-	PollInterval    *int           `json:"pollInterval,omitempty"`
+	PollInterval    *Seconds       `json:"pollInterval,omitempty"`
 	MaxPollAttempts *int           `json:"maxPollAttempts,omitempty"`
 }
 
 type ExternalApiConfig struct {
-	APIType       string            `json:"apiType"`
-	Endpoint      *string           `json:"endpoint,omitempty"`
-	IntegrationID *string           `json:"integrationId,omitempty"`
-	Method        *HTTPMethod       `json:"method,omitempty"`
-	Headers       map[string]string `json:"headers,omitempty"`
-	Payload       map[string]any    `json:"payload,omitempty"`
-	Timeout       *int              `json:"timeout,omitempty"`
-	UseContextFrom *string          `json:"useContextFrom,omitempty"`
+	APIType        string            `json:"apiType"`
+	Endpoint       *string           `json:"endpoint,omitempty"`
+	IntegrationID  *string           `json:"integrationId,omitempty"`
+	Method         *HTTPMethod       `json:"method,omitempty"`
+	Headers        map[string]string `json:"headers,omitempty"`
+	Payload        map[string]any    `json:"payload,omitempty"`
+	Timeout        *Millis           `json:"timeout,omitempty"`
+	UseContextFrom *string           `json:"useContextFrom,omitempty"`
 }
 
 type WaitConfig struct {
-	Duration int     `json:"duration"`
+	Duration Seconds `json:"duration"`
 	WaitType *string `json:"waitType,omitempty"`
 }
 
@@ -171,7 +225,7 @@ type ConditionalConfig struct {
 
 type EscalateConfig struct {
 	MaxAttempts   int     `json:"maxAttempts"`
-	EscalateAfter int     `json:"escalateAfter"`
+	EscalateAfter Seconds `json:"escalateAfter"`
 	ResetToRuleID *string `json:"resetToRuleId,omitempty"`
 }
 
@@ -182,16 +236,18 @@ type CreateRelayRuleInput struct {
 	Group       *string        `json:"group,omitempty"`
 	Order       *int           `json:"order,omitempty"`
 	Config      map[string]any `json:"config"`
+	TypedConfig RuleConfig     `json:"-"`
 	Enabled     *bool          `json:"enabled,omitempty"`
 }
 
 type UpdateRelayRuleInput struct {
-	Name     *string        `json:"name,omitempty"`
-	RuleType *RelayRuleType `json:"ruleType,omitempty"`
-	Group    *string        `json:"group,omitempty"`
-	Order    *int           `json:"order,omitempty"`
-	Config   map[string]any `json:"config,omitempty"`
-	Enabled  *bool          `json:"enabled,omitempty"`
+	Name        *string        `json:"name,omitempty"`
+	RuleType    *RelayRuleType `json:"ruleType,omitempty"`
+	Group       *string        `json:"group,omitempty"`
+	Order       *int           `json:"order,omitempty"`
+	Config      map[string]any `json:"config,omitempty"`
+	TypedConfig RuleConfig     `json:"-"`
+	Enabled     *bool          `json:"enabled,omitempty"`
 }
 
 type RelayRule struct {
@@ -213,20 +269,34 @@ type RelayRule struct {
 type ListRelayRulesParams struct {
 	Enabled  *bool          `json:"enabled,omitempty"`
 	RuleType *RelayRuleType `json:"ruleType,omitempty"`
+	Group    *string        `json:"group,omitempty"`
+}
+
+// RuleGroup is a named set of rules of a relay. Rules without a group form
+// the group with an empty name.
+type RuleGroup struct {
+	Name  string
+	Rules []RelayRule
+}
+
+type RuleOrder struct {
+	ID    string `json:"id"`
+	Order int    `json:"order"`
 }
 
 type ReorderRelayRulesInput struct {
-	Rules []struct {
-		ID    string `json:"id"`
-		Order int    `json:"order"`
-	} `json:"rules"`
+	Rules []RuleOrder `json:"rules"`
 }
 
 type ContactMethodTransport string
 
 const (
-	TransportEmail ContactMethodTransport = "email"
-	TransportSMS   ContactMethodTransport = "sms"
+	TransportEmail   ContactMethodTransport = "email"
+	TransportSMS     ContactMethodTransport = "sms"
+	TransportVoice   ContactMethodTransport = "voice"
+	TransportPush    ContactMethodTransport = "push"
+	TransportSlack   ContactMethodTransport = "slack"
+	TransportWebhook ContactMethodTransport = "webhook"
 )
 
 type CreateContactMethodInput struct {
@@ -254,11 +324,34 @@ type DeleteContactMethodParams struct {
 	UserID string `json:"userId"`
 }
 
+type UpdateContactMethodInput struct {
+	UserID string  `json:"userId"`
+	Value  *string `json:"value,omitempty"`
+	// Transport is the method's current transport. It is not sent; when set,
+	// a new Value is validated against it without looking the method up.
+	Transport ContactMethodTransport `json:"-"`
+}
+
+type SendContactMethodVerificationInput struct {
+	UserID string `json:"userId"`
+}
+
+type ConfirmContactMethodVerificationInput struct {
+	UserID string `json:"userId"`
+	Code   string `json:"code"`
+}
+
+type SendContactMethodTestInput struct {
+	UserID string `json:"userId"`
+}
+
 type OnCallUser struct {
-	UserID           string `json:"userId"`
-	Email            string `json:"email"`
-	AssignmentNumber int    `json:"assignmentNumber"`
-	ScheduleID       string `json:"scheduleId"`
+	UserID           string  `json:"userId"`
+	Email            string  `json:"email"`
+	AssignmentNumber int     `json:"assignmentNumber"`
+	ScheduleID       string  `json:"scheduleId"`
+	IsOverride       bool    `json:"isOverride"`
+	OverrideID       *string `json:"overrideId,omitempty"`
 }
 
 type AlertSeverity string
@@ -285,14 +378,122 @@ type Alert struct {
 	AcknowledgedAt   *time.Time     `json:"acknowledgedAt,omitempty"`
 	AcknowledgedBy   *string        `json:"acknowledgedBy,omitempty"`
 	ResolvedAt       *time.Time     `json:"resolvedAt,omitempty"`
+	SnoozedUntil     *time.Time     `json:"snoozedUntil,omitempty"`
 	CreatedAt        time.Time      `json:"createdAt"`
 	UpdatedAt        time.Time      `json:"updatedAt"`
 }
 
+type TriggerAlertInput struct {
+	Title      string         `json:"title"`
+	Message    *string        `json:"message,omitempty"`
+	Severity   AlertSeverity  `json:"severity"`
+	Source     string         `json:"source"`
+	ExternalID *string        `json:"externalId,omitempty"`
+	Metadata   map[string]any `json:"metadata,omitempty"`
+}
+
+type TriggerAlertResult struct {
+	Alert        Alert `json:"alert"`
+	Deduplicated bool  `json:"deduplicated"`
+}
+
 type AcknowledgeAlertInput struct {
 	UserID *string `json:"userId,omitempty"`
 }
 
+type AlertFilter struct {
+	Severities []AlertSeverity
+	Source     *string
+	Match      func(Alert) bool
+}
+
+type BulkAlertInput struct {
+	AlertIDs    []string
+	Filter      *AlertFilter
+	Concurrency int
+}
+
+type AddAlertNoteInput struct {
+	Body   string  `json:"body"`
+	UserID *string `json:"userId,omitempty"`
+}
+
+type AlertNote struct {
+	ID        string    `json:"id"`
+	AlertID   string    `json:"alertId"`
+	UserID    *string   `json:"userId,omitempty"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type AlertEventType string
+
+const (
+	AlertEventTriggered      AlertEventType = "triggered"
+	AlertEventNotified       AlertEventType = "notified"
+	AlertEventAcknowledged   AlertEventType = "acknowledged"
+	AlertEventUnacknowledged AlertEventType = "unacknowledged"
+	AlertEventAssigned       AlertEventType = "assigned"
+	AlertEventEscalated      AlertEventType = "escalated"
+	AlertEventNoteAdded      AlertEventType = "note_added"
+	AlertEventResolved       AlertEventType = "resolved"
+	AlertEventReopened       AlertEventType = "reopened"
+	AlertEventSnoozed        AlertEventType = "snoozed"
+)
+
+type AlertEvent struct {
+	ID                 string              `json:"id"`
+	AlertID            string              `json:"alertId"`
+	Type               AlertEventType      `json:"type"`
+	ActorUserID        *string             `json:"actorUserId,omitempty"`
+	RelayRuleID        *string             `json:"relayRuleId,omitempty"`
+	TargetUserID       *string             `json:"targetUserId,omitempty"`
+	NotificationMethod *NotificationMethod `json:"notificationMethod,omitempty"`
+	Message            *string             `json:"message,omitempty"`
+	Metadata           map[string]any      `json:"metadata,omitempty"`
+	CreatedAt          time.Time           `json:"createdAt"`
+}
+
+type CreateWebhookInput struct {
+	Name    string `json:"name"`
+	RelayID string `json:"relayId"`
+}
+
+type UpdateWebhookInput struct {
+	Name    *string `json:"name,omitempty"`
+	RelayID *string `json:"relayId,omitempty"`
+}
+
+type Webhook struct {
+	ID             string     `json:"id"`
+	OrganizationID string     `json:"organizationId"`
+	Name           string     `json:"name"`
+	RelayID        string     `json:"relayId"`
+	Secret         string     `json:"secret"`
+	IngestURL      string     `json:"ingestUrl"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+	DeletedAt      *time.Time `json:"deletedAt,omitempty"`
+}
+
+type UserRole string
+
+const (
+	UserRoleOwner  UserRole = "owner"
+	UserRoleAdmin  UserRole = "admin"
+	UserRoleMember UserRole = "member"
+)
+
+type User struct {
+	ID             string    `json:"id"`
+	OrganizationID string    `json:"organizationId"`
+	Email          string    `json:"email"`
+	Name           string    `json:"name"`
+	Role           UserRole  `json:"role"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
 type IntegrationProvider string
 
 const (
@@ -0,0 +1,100 @@
+package oncall
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+type UserResource struct {
+	http *httpClient
+}
+
+func newUserResource(http *httpClient) *UserResource {
+	return &UserResource{http: http}
+}
+
+func (u *UserResource) List(ctx context.Context) ([]User, error) {
+	var result struct {
+		Users []User `json:"users"`
+	}
+	if err := u.http.get(ctx, "/users", &result); err != nil {
+		return nil, err
+	}
+	return result.Users, nil
+}
+
+func (u *UserResource) Get(ctx context.Context, userID string) (*User, error) {
+	var result struct {
+		User User `json:"user"`
+	}
+	path := fmt.Sprintf("/users/%s", userID)
+	if err := u.http.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+	return &result.User, nil
+}
+
+func (u *UserResource) GetByEmail(ctx context.Context, email string) (*User, error) {
+	query := url.Values{}
+	query.Set("email", email)
+	path := fmt.Sprintf("/users?%s", query.Encode())
+
+	var result struct {
+		Users []User `json:"users"`
+	}
+	if err := u.http.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+	// Check the address ourselves in case the server ignores the filter.
+	for i := range result.Users {
+		if strings.EqualFold(result.Users[i].Email, email) {
+			return &result.Users[i], nil
+		}
+	}
+	return nil, &NotFoundError{OnCallError: OnCallError{Message: fmt.Sprintf("user with email %s not found", email)}}
+}
+
+// Me returns the user the client's API key belongs to.
+func (u *UserResource) Me(ctx context.Context) (*User, error) {
+	var result struct {
+		User User `json:"user"`
+	}
+	if err := u.http.get(ctx, "/users/me", &result); err != nil {
+		return nil, err
+	}
+	return &result.User, nil
+}
+
+func (u *UserResource) ListSafe(ctx context.Context) Result[[]User] {
+	users, err := u.List(ctx)
+	if err != nil {
+		return Result[[]User]{Error: err}
+	}
+	return Result[[]User]{Data: &users}
+}
+
+func (u *UserResource) GetSafe(ctx context.Context, userID string) Result[User] {
+	user, err := u.Get(ctx, userID)
+	if err != nil {
+		return Result[User]{Error: err}
+	}
+	return Result[User]{Data: user}
+}
+
+func (u *UserResource) GetByEmailSafe(ctx context.Context, email string) Result[User] {
+	user, err := u.GetByEmail(ctx, email)
+	if err != nil {
+		return Result[User]{Error: err}
+	}
+	return Result[User]{Data: user}
+}
+
+func (u *UserResource) MeSafe(ctx context.Context) Result[User] {
+	user, err := u.Me(ctx)
+	if err != nil {
+		return Result[User]{Error: err}
+	}
+	return Result[User]{Data: user}
+}
@@ -0,0 +1,35 @@
+package oncall
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestGetUserByEmail(t *testing.T) {
+	users := `{"users":[{"id":"u1","email":"alice@example.com"},{"id":"u2","email":"Bob@Example.com"}]}`
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/users" || r.URL.Query().Get("email") == "" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		// Ignore the filter, as a server without email filtering would.
+		w.Write([]byte(users))
+	})
+	ctx := context.Background()
+
+	user, err := client.User.GetByEmail(ctx, "bob@example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.ID != "u2" {
+		t.Fatalf("expected u2, got %+v", user)
+	}
+
+	var nf *NotFoundError
+	if _, err := client.User.GetByEmail(ctx, "carol@example.com"); !errors.As(err, &nf) {
+		t.Fatalf("expected NotFoundError for an address not returned, got %v", err)
+	}
+}
@@ -0,0 +1,246 @@
+package oncall
+
+import (
+	"errors"
+	"fmt"
+)
+
+type fieldErrors []FieldError
+
+func (f *fieldErrors) add(path, format string, args ...any) {
+	*f = append(*f, FieldError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// merge adds the field errors of a nested Validate call under prefix.
+func (f *fieldErrors) merge(prefix string, err error) {
+	if err == nil {
+		return
+	}
+	var verr *ValidationError
+	if errors.As(err, &verr) && len(verr.Fields) > 0 {
+		for _, fe := range verr.Fields {
+			f.add(prefix+"."+fe.Path, "%s", fe.Message)
+		}
+		return
+	}
+	f.add(prefix, "%s", err.Error())
+}
+
+func (f fieldErrors) err() error {
+	if len(f) == 0 {
+		return nil
+	}
+	return newFieldValidationError(f)
+}
+
+func requireExternalKey(key *string) error {
+	var errs fieldErrors
+	if key == nil || *key == "" {
+		errs.add("externalKey", "is required")
+	}
+	return errs.err()
+}
+
+func (c ScheduleNotifyConfig) Validate() error {
+	var errs fieldErrors
+	if c.ScheduleID == "" {
+		errs.add("scheduleId", "is required")
+	}
+	if c.NotificationMethod != nil && !c.NotificationMethod.valid() {
+		errs.add("notificationMethod", "unknown notification method %q", *c.NotificationMethod)
+	}
+	return errs.err()
+}
+
+func (c WebhookConfig) Validate() error {
+	var errs fieldErrors
+	if err := validateAbsoluteURL(c.Endpoint); err != nil {
+		errs.add("endpoint", "must be an absolute http or https URL")
+	}
+	if c.Method != nil && !c.Method.valid() {
+		errs.add("method", "unknown HTTP method %q", *c.Method)
+	}
+	if c.Timeout != nil && *c.Timeout <= 0 {
+		errs.add("timeout", "must be positive")
+	}
+	return errs.err()
+}
+
+func (c AgentConfig) Validate() error {
+	var errs fieldErrors
+	if c.AgentType != AgentTypeDevin && c.AgentType != AgentTypeRhythm {
+		errs.add("agentType", "unknown agent type %q", c.AgentType)
+	}
+	if c.IntegrationID == nil && c.Endpoint == nil {
+		errs.add("integrationId", "integrationId or endpoint is required")
+	}
+	if c.IntegrationID != nil && *c.IntegrationID == "" {
+		errs.add("integrationId", "must not be empty")
+	}
+	if c.Endpoint != nil && validateAbsoluteURL(*c.Endpoint) != nil {
+		errs.add("endpoint", "must be an absolute http or https URL")
+	}
+	if c.PollInterval != nil && *c.PollInterval <= 0 {
+		errs.add("pollInterval", "must be positive")
+	}
+	if c.MaxPollAttempts != nil && *c.MaxPollAttempts <= 0 {
+		errs.add("maxPollAttempts", "must be positive")
+	}
+	return errs.err()
+}
+
+func (c ExternalApiConfig) Validate() error {
+	var errs fieldErrors
+	if c.APIType == "" {
+		errs.add("apiType", "is required")
+	}
+	if c.IntegrationID == nil && c.Endpoint == nil {
+		errs.add("endpoint", "endpoint or integrationId is required")
+	}
+	if c.Endpoint != nil && validateAbsoluteURL(*c.Endpoint) != nil {
+		errs.add("endpoint", "must be an absolute http or https URL")
+	}
+	if c.Method != nil && !c.Method.valid() {
+		errs.add("method", "unknown HTTP method %q", *c.Method)
+	}
+	if c.Timeout != nil && *c.Timeout <= 0 {
+		errs.add("timeout", "must be positive")
+	}
+	return errs.err()
+}
+
+func (c WaitConfig) Validate() error {
+	var errs fieldErrors
+	if c.Duration <= 0 {
+		errs.add("duration", "must be positive")
+	}
+	return errs.err()
+}
+
+func (c ConditionalConfig) Validate() error {
+	var errs fieldErrors
+	if c.Condition == "" {
+		errs.add("condition", "is required")
+	}
+	if c.TrueRuleID != nil && *c.TrueRuleID == "" {
+		errs.add("trueRuleId", "must not be empty")
+	}
+	if c.FalseRuleID != nil && *c.FalseRuleID == "" {
+		errs.add("falseRuleId", "must not be empty")
+	}
+	return errs.err()
+}
+
+func (c EscalateConfig) Validate() error {
+	var errs fieldErrors
+	if c.MaxAttempts < 1 {
+		errs.add("maxAttempts", "must be at least 1")
+	}
+	if c.EscalateAfter < 0 {
+		errs.add("escalateAfter", "must not be negative")
+	}
+	if c.ResetToRuleID != nil && *c.ResetToRuleID == "" {
+		errs.add("resetToRuleId", "must not be empty")
+	}
+	return errs.err()
+}
+
+func (in CreateRelayRuleInput) Validate() error {
+	var errs fieldErrors
+	if in.Name == "" {
+		errs.add("name", "is required")
+	}
+	if in.Order != nil && *in.Order < 0 {
+		errs.add("order", "must not be negative")
+	}
+
+	switch {
+	case nilRuleConfig(in.TypedConfig):
+		errs.add("config", "TypedConfig must not be a nil pointer")
+	case in.TypedConfig != nil:
+		if in.RuleType != "" && in.RuleType != in.TypedConfig.RuleType() {
+			errs.add("ruleType", "does not match %s config", in.TypedConfig.RuleType())
+		}
+		errs.merge("config", validateRuleConfig(in.TypedConfig))
+	case in.RuleType == "":
+		errs.add("ruleType", "is required")
+	case !in.RuleType.valid():
+		errs.add("ruleType", "unknown rule type %q", in.RuleType)
+	default:
+		errs.merge("config", validateRuleConfigMap(in.RuleType, in.Config))
+	}
+	return errs.err()
+}
+
+func (in UpdateRelayRuleInput) Validate() error {
+	var errs fieldErrors
+	if in.Name != nil && *in.Name == "" {
+		errs.add("name", "must not be empty")
+	}
+	if in.Order != nil && *in.Order < 0 {
+		errs.add("order", "must not be negative")
+	}
+	if in.RuleType != nil && !in.RuleType.valid() {
+		errs.add("ruleType", "unknown rule type %q", *in.RuleType)
+	}
+
+	switch {
+	case nilRuleConfig(in.TypedConfig):
+		errs.add("config", "TypedConfig must not be a nil pointer")
+	case in.TypedConfig != nil:
+		if in.RuleType != nil && *in.RuleType != in.TypedConfig.RuleType() {
+			errs.add("ruleType", "does not match %s config", in.TypedConfig.RuleType())
+		}
+		errs.merge("config", validateRuleConfig(in.TypedConfig))
+	case in.Config != nil && in.RuleType != nil && in.RuleType.valid():
+		errs.merge("config", validateRuleConfigMap(*in.RuleType, in.Config))
+	}
+	return errs.err()
+}
+
+func validateRuleConfig(cfg RuleConfig) error {
+	if v, ok := cfg.(interface{ Validate() error }); ok {
+		return v.Validate()
+	}
+	return nil
+}
+
+func validateRuleConfigMap(ruleType RelayRuleType, config map[string]any) error {
+	if config == nil {
+		return errors.New("is required")
+	}
+	cfg, err := RelayRule{RuleType: ruleType, Config: config}.TypedConfig()
+	if err != nil {
+		if inner := errors.Unwrap(err); inner != nil {
+			err = inner
+		}
+		return fmt.Errorf("does not match %s: %v", ruleType, err)
+	}
+	return validateRuleConfig(cfg)
+}
+
+func (t RelayRuleType) valid() bool {
+	switch t {
+	case RuleTypeScheduleNotify, RuleTypeWebhook, RuleTypeAgent, RuleTypeExternalAPI,
+		RuleTypeWait, RuleTypeConditional, RuleTypeEscalate:
+		return true
+	}
+	return false
+}
+
+func (m NotificationMethod) valid() bool {
+	switch m {
+	case NotificationEmail, NotificationSMS, NotificationVoice, NotificationPush,
+		NotificationSlack, NotificationWebhook:
+		return true
+	}
+	return false
+}
+
+func (m HTTPMethod) valid() bool {
+	switch m {
+	case MethodGET, MethodPOST, MethodPUT, MethodPATCH, MethodDELETE:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package oncall
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestRuleConfigValidate(t *testing.T) {
+	relative := "/agent"
+	tests := []struct {
+		name      string
+		cfg       interface{ Validate() error }
+		wantPaths []string
+	}{
+		{"zero wait", WaitConfig{}, []string{"duration"}},
+		{"relative webhook", WebhookConfig{Endpoint: "/hook"}, []string{"endpoint"}},
+		{"agent without target", AgentConfig{AgentType: AgentTypeDevin}, []string{"integrationId"}},
+		{"agent relative endpoint", AgentConfig{AgentType: AgentTypeDevin, Endpoint: &relative}, []string{"endpoint"}},
+		{"escalate without attempts", EscalateConfig{}, []string{"maxAttempts"}},
+		{"valid wait", WaitConfig{Duration: 300}, nil},
+		{"valid webhook", WebhookConfig{Endpoint: "https://example.com/hook"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantPaths == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("expected ValidationError, got %v", err)
+			}
+			if len(verr.Fields) != len(tt.wantPaths) {
+				t.Fatalf("expected fields %v, got %v", tt.wantPaths, verr.Fields)
+			}
+			for i, path := range tt.wantPaths {
+				if verr.Fields[i].Path != path {
+					t.Fatalf("expected field %s, got %s", path, verr.Fields[i].Path)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateRelayRuleValidation(t *testing.T) {
+	input := CreateRelayRuleInput{
+		Name:     "wait",
+		RuleType: RuleTypeWait,
+		Config:   map[string]any{"duration": 0},
+	}
+
+	t.Run("rejects invalid input before sending", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			t.Fatalf("unexpected request %s", r.URL.Path)
+		})
+		_, err := client.Relay.Rules.Create(context.Background(), "relay1", input)
+		var verr *ValidationError
+		if !errors.As(err, &verr) || verr.Fields[0].Path != "config.duration" {
+			t.Fatalf("expected config.duration validation error, got %v", err)
+		}
+	})
+
+	t.Run("can be skipped", func(t *testing.T) {
+		client := newTestClientWithConfig(t, Config{SkipRuleValidation: true}, func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"rule":{"id":"rule1"}}`))
+		})
+		if _, err := client.Relay.Rules.Create(context.Background(), "relay1", input); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
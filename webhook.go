@@ -0,0 +1,129 @@
+package oncall
+
+import (
+	"context"
+	"fmt"
+)
+
+type WebhookResource struct {
+	http *httpClient
+}
+
+func newWebhookResource(http *httpClient) *WebhookResource {
+	return &WebhookResource{http: http}
+}
+
+func (w *WebhookResource) List(ctx context.Context) ([]Webhook, error) {
+	var result struct {
+		Webhooks []Webhook `json:"webhooks"`
+	}
+	if err := w.http.get(ctx, "/webhooks", &result); err != nil {
+		return nil, err
+	}
+	return result.Webhooks, nil
+}
+
+func (w *WebhookResource) Create(ctx context.Context, input CreateWebhookInput) (*Webhook, error) {
+	var result struct {
+		Webhook Webhook `json:"webhook"`
+	}
+	if err := w.http.post(ctx, "/webhooks", input, &result); err != nil {
+		return nil, err
+	}
+	return &result.Webhook, nil
+}
+
+func (w *WebhookResource) Get(ctx context.Context, webhookID string) (*Webhook, error) {
+	var result struct {
+		Webhook Webhook `json:"webhook"`
+	}
+	path := fmt.Sprintf("/webhooks/%s", webhookID)
+	if err := w.http.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+	return &result.Webhook, nil
+}
+
+func (w *WebhookResource) Update(ctx context.Context, webhookID string, input UpdateWebhookInput) (*Webhook, error) {
+	var result struct {
+		Webhook Webhook `json:"webhook"`
+	}
+	path := fmt.Sprintf("/webhooks/%s", webhookID)
+	if err := w.http.put(ctx, path, input, &result); err != nil {
+		return nil, err
+	}
+	return &result.Webhook, nil
+}
+
+func (w *WebhookResource) Delete(ctx context.Context, webhookID string) error {
+	var result struct {
+		Success bool `json:"success"`
+	}
+	path := fmt.Sprintf("/webhooks/%s", webhookID)
+	if err := w.http.delete(ctx, path, &result); err != nil {
+		return err
+	}
+	return nil
+}
+
+// RotateSecret issues a new signing secret. The previous secret stops being
+// accepted as soon as the call returns.
+func (w *WebhookResource) RotateSecret(ctx context.Context, webhookID string) (*Webhook, error) {
+	var result struct {
+		Webhook Webhook `json:"webhook"`
+	}
+	path := fmt.Sprintf("/webhooks/%s/rotate-secret", webhookID)
+	if err := w.http.post(ctx, path, struct{}{}, &result); err != nil {
+		return nil, err
+	}
+	return &result.Webhook, nil
+}
+
+func (w *WebhookResource) ListSafe(ctx context.Context) Result[[]Webhook] {
+	webhooks, err := w.List(ctx)
+	if err != nil {
+		return Result[[]Webhook]{Error: err}
+	}
+	return Result[[]Webhook]{Data: &webhooks}
+}
+
+func (w *WebhookResource) CreateSafe(ctx context.Context, input CreateWebhookInput) Result[Webhook] {
+	webhook, err := w.Create(ctx, input)
+	if err != nil {
+		return Result[Webhook]{Error: err}
+	}
+	return Result[Webhook]{Data: webhook}
+}
+
+func (w *WebhookResource) GetSafe(ctx context.Context, webhookID string) Result[Webhook] {
+	webhook, err := w.Get(ctx, webhookID)
+	if err != nil {
+		return Result[Webhook]{Error: err}
+	}
+	return Result[Webhook]{Data: webhook}
+}
+
+func (w *WebhookResource) UpdateSafe(ctx context.Context, webhookID string, input UpdateWebhookInput) Result[Webhook] {
+	webhook, err := w.Update(ctx, webhookID, input)
+	if err != nil {
+		return Result[Webhook]{Error: err}
+	}
+	return Result[Webhook]{Data: webhook}
+}
+
+func (w *WebhookResource) DeleteSafe(ctx context.Context, webhookID string) Result[bool] {
+	err := w.Delete(ctx, webhookID)
+	if err != nil {
+		return Result[bool]{Error: err}
+	}
+	success := true
+	return Result[bool]{Data: &success}
+}
+
+func (w *WebhookResource) RotateSecretSafe(ctx context.Context, webhookID string) Result[Webhook] {
+	webhook, err := w.RotateSecret(ctx, webhookID)
+	if err != nil {
+		return Result[Webhook]{Error: err}
+	}
+	return Result[Webhook]{Data: webhook}
+}
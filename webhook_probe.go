@@ -0,0 +1,86 @@
+package oncall
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultWebhookTimeout  = 10 * time.Second
+	maxWebhookResponseBody = 1 << 20
+)
+
+type WebhookTestResult struct {
+	Request    *RenderedRequest
+	StatusCode int
+	Headers    http.Header
+	// Body holds up to the first MiB of the response.
+	Body    []byte
+	Latency time.Duration
+}
+
+// OK reports whether the receiver answered with a 2xx status.
+func (r *WebhookTestResult) OK() bool {
+	return r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// TestWebhook renders a request for sampleAlert with RenderRequest, which
+// approximates what the webhook rule sends, and sends it to the configured
+// endpoint, honouring Method, Headers and Timeout (in milliseconds; 10 seconds
+// if unset, and a ValidationError if not positive). The request goes out
+// through http.DefaultClient, not the API client, so it uses the process's
+// default transport and proxy settings. A response is returned whatever its
+// status; only transport failures, rendering and validation errors are
+// returned as errors.
+func TestWebhook(ctx context.Context, cfg WebhookConfig, sampleAlert Alert) (*WebhookTestResult, error) {
+	timeout := defaultWebhookTimeout
+	if cfg.Timeout != nil {
+		if *cfg.Timeout <= 0 {
+			var errs fieldErrors
+			errs.add("timeout", "must be positive")
+			return nil, errs.err()
+		}
+		timeout = cfg.Timeout.Duration()
+	}
+
+	rendered, err := RenderRequest(cfg, sampleAlert)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := rendered.NewHTTPRequest(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxWebhookResponseBody))
+	if err != nil {
+		return nil, err
+	}
+	return &WebhookTestResult{
+		Request:    rendered,
+		StatusCode: resp.StatusCode,
+		Headers:    resp.Header,
+		Body:       body,
+		Latency:    time.Since(start),
+	}, nil
+}
+
+func TestWebhookSafe(ctx context.Context, cfg WebhookConfig, sampleAlert Alert) Result[WebhookTestResult] {
+	result, err := TestWebhook(ctx, cfg, sampleAlert)
+	if err != nil {
+		return Result[WebhookTestResult]{Error: err}
+	}
+	return Result[WebhookTestResult]{Data: result}
+}
@@ -0,0 +1,63 @@
+package oncall
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTestWebhook(t *testing.T) {
+	var got struct {
+		method string
+		token  string
+		body   map[string]any
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got.method = r.Method
+		got.token = r.Header.Get("X-Token")
+		json.NewDecoder(r.Body).Decode(&got.body)
+		if r.URL.Path == "/slow" {
+			time.Sleep(200 * time.Millisecond)
+		}
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte("queued"))
+	}))
+	defer server.Close()
+
+	put := MethodPUT
+	cfg := WebhookConfig{
+		Endpoint: server.URL + "/hook",
+		Method:   &put,
+		Headers:  map[string]string{"X-Token": "secret"},
+		Payload:  map[string]any{"title": "{{alert.title}}"},
+	}
+	result, err := TestWebhook(context.Background(), cfg, Alert{Title: "Disk full"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.OK() || result.StatusCode != http.StatusAccepted || string(result.Body) != "queued" || result.Latency <= 0 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if got.method != "PUT" || got.token != "secret" || got.body["title"] != "Disk full" {
+		t.Fatalf("unexpected request: %+v", got)
+	}
+
+	timeout := Millis(50)
+	cfg.Endpoint = server.URL + "/slow"
+	cfg.Timeout = &timeout
+	if _, err := TestWebhook(context.Background(), cfg, Alert{Title: "Disk full"}); err == nil {
+		t.Fatal("expected timeout error")
+	}
+
+	var verr *ValidationError
+	for _, timeout := range []Millis{0, -1} {
+		cfg.Timeout = &timeout
+		if _, err := TestWebhook(context.Background(), cfg, Alert{Title: "Disk full"}); !errors.As(err, &verr) {
+			t.Fatalf("expected ValidationError for timeout %d, got %v", timeout, err)
+		}
+	}
+}